@@ -1,10 +1,12 @@
 package main
 
 import (
+	"context"
 	"crypto/sha256"
 	"encoding/json"
 	"fmt"
 	"log"
+	"os"
 	"strings"
 	"time"
 
@@ -13,9 +15,36 @@ import (
 	"github.com/dantoye/throwpro/throwlib"
 )
 
-func guess(req events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+// how often to check the parameter bundle for a retune
+const PARAMS_REFRESH = 5 * time.Minute
+
+// time kept back from the lambda deadline to write out a partial guess
+const DEADLINE_MARGIN = 500 * time.Millisecond
+
+var params *throwlib.ParamsWatcher
+
+var errTooManyClips = &throwlib.Error{
+	Code:    throwlib.ErrCodeTooManyClips,
+	Message: fmt.Sprintf("at most %d clips are guessed from", throwlib.API_MAX_CLIPS),
+}
+
+// failed answers with a reset and the reason for it.
+func failed(res events.APIGatewayProxyResponse, status int, err error) events.APIGatewayProxyResponse {
+	enc, _ := json.Marshal(throwlib.Response{Method: "reset", Error: throwlib.AsError(err)})
+	res.Body = string(enc)
+	res.StatusCode = status
+	return res
+}
+
+func guess(ctx context.Context, req events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
 	res := events.APIGatewayProxyResponse{}
 
+	if params != nil {
+		if _, err := params.Refresh(); err != nil {
+			log.Println("keeping params", throwlib.ActiveParams().Version, "after error:", err.Error())
+		}
+	}
+
 	throws := throwlib.Request{}
 	if err := json.Unmarshal([]byte(req.Body), &throws); err != nil {
 		return res, err
@@ -32,24 +61,37 @@ func guess(req events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, e
 	log.Println("session", sessionKey, "mapped to", throws.Session)
 
 	if len(throws.Clips) == 0 {
-		res.Body = `{"method":"reset"}`
-		res.StatusCode = 400
-		return res, nil
+		return failed(res, 400, throwlib.ErrNoThrows), nil
 	}
 
-	if len(throws.Clips) > 5 {
-		res.Body = `{"method":"reset"}`
-		res.StatusCode = 400
-		return res, nil
+	if len(throws.Clips) > throwlib.API_MAX_CLIPS {
+		return failed(res, 400, errTooManyClips), nil
 	}
 
-	response := throwlib.NewResponse(throws)
+	if deadline, ok := ctx.Deadline(); ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithDeadline(ctx, deadline.Add(-DEADLINE_MARGIN))
+		defer cancel()
+	}
+	response, err := throwlib.NewResponseContext(ctx, throws)
+	if response.Trace != nil {
+		trace, _ := json.Marshal(response.Trace)
+		log.Println("trace", string(trace))
+	}
 	enc, _ := json.MarshalIndent(response, "", "\t")
 	res.Body = string(enc)
 	res.StatusCode = 200
+	if err != nil {
+		// the response carries the error along with its diagnostics
+		res.StatusCode = 422
+	}
 	return res, nil
 }
 
 func main() {
+	// a file or URL with a parameter bundle, otherwise the built in one is used
+	if source := os.Getenv("THROWPRO_PARAMS"); source != "" {
+		params = &throwlib.ParamsWatcher{Source: source, Every: PARAMS_REFRESH}
+	}
 	lambda.Start(guess)
 }
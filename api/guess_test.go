@@ -1,9 +1,12 @@
 package main
 
 import (
+	"context"
+	"encoding/json"
 	"testing"
 
 	"github.com/aws/aws-lambda-go/events"
+	"github.com/dantoye/throwpro/throwlib"
 )
 
 func TestGuess(t *testing.T) {
@@ -12,9 +15,25 @@ func TestGuess(t *testing.T) {
 		"/execute in minecraft:overworld run tp @s 294.96 116.93 -486.85 -499.05 -25.35",
 		"/execute in minecraft:overworld run tp @s -456.90 116.93 120.37 -752.41 -31.65"
 	]}`
-	res, err := guess(req)
+	res, err := guess(context.Background(), req)
 	if err != nil {
 		t.Fatal(err.Error())
 	}
 	t.Log(res.Body)
 }
+
+func TestGuessError(t *testing.T) {
+	req := events.APIGatewayProxyRequest{}
+	req.Body = `{"clips":["not a clip"]}`
+	res, err := guess(context.Background(), req)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	out := throwlib.Response{}
+	if err := json.Unmarshal([]byte(res.Body), &out); err != nil {
+		t.Fatal(err.Error())
+	}
+	if res.StatusCode != 422 || out.Error == nil || out.Error.Code != throwlib.ErrCodeNoThrows {
+		t.Errorf("expected a structured error, got %d %s", res.StatusCode, res.Body)
+	}
+}
@@ -3,12 +3,15 @@ package main
 //go:generate sh -c "(printf 'package main\nvar icon string=`'; base64 eye.png; printf '`') >Icon.go"
 
 import (
+	"context"
 	"encoding/base64"
+	"encoding/json"
 	"fmt"
 	"log"
 	"math"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"time"
 
@@ -19,6 +22,9 @@ import (
 	"github.com/dantoye/throwpro/throwlib"
 )
 
+// longest a guess may take, online or off, before settling for a partial one
+const SOLVE_BUDGET = 2 * time.Second
+
 var name = lns(`ThrowPro Minecraft Assistant`, `Version 0.7`)
 
 var BLURB = lns(
@@ -32,6 +38,7 @@ var BLURB = lns(
 	`2. Don't look up at the sky if you want a blind guess.`,
 	`3. Cracked mode is wild. Lower your FOV and sensitivity before using.`,
 	`4. Offline mode runs the predictions on your computer.`,
+	`5. Found Stronghold learns from an F3+C on the starter staircase.`,
 	``,
 	`For further help, message @Cudduw or open an issue on the github repo.`,
 )
@@ -39,8 +46,8 @@ var BLURB = lns(
 var FORMATS = map[string]string{
 	"blind":         `{nether} nether to go {distance} blocks {line}({coords} overworld)`,
 	"educated":      `{nether} nether to go {distance} blocks {line}({coords} overworld)`,
-	"triangulation": `{coords} is {confidence} likely {line}({distance} away, {nether} nether)`,
-	"hyper":         `{nether} nether is {distance} blocks {line}({coords} overworld, {confidence} likely)`,
+	"triangulation": `{coords} is {confidence} likely {line}({distance}{uncertainty} away, {nether} nether)`,
+	"hyper":         `{nether} nether is {distance}{uncertainty} blocks {line}({coords} overworld, {confidence} likely)`,
 }
 
 var METHODS = map[string]string{
@@ -93,8 +100,25 @@ func (m *Monitor) Block() {
 		m.clips = append(m.clips, text)
 		req := throwlib.Request{Clips: m.clips}
 		req.Options.Hyper = m.Display.Options.CrackedMode
-		res := throwlib.PostRequest(req, m.Display.Options.OfflineMode)
+		req.Options.Version = m.Display.Options.Version
+		req.Options.FOV = m.Display.Options.FOV
+		req.Options.Sensitivity = m.Display.Options.Sensitivity
+		req.Options.Selection = m.Display.Options.Selection
+		req.Options.Trace = m.Display.Options.Trace
+		req.Options.Calibration = m.Display.calibration
+		if m.Display.params != nil {
+			if _, err := m.Display.params.Refresh(); err != nil {
+				log.Println("keeping params", throwlib.ActiveParams().Version, "after error:", err.Error())
+			}
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), SOLVE_BUDGET)
+		res, err := throwlib.PostRequestContext(ctx, req, m.Display.Options.OfflineMode)
+		cancel()
 		m.clips = res.Keep
+		if err != nil {
+			m.Display.Fail(throwlib.AsError(err))
+			continue
+		}
 		m.Display.Refresh(res)
 		m.ExtendTimer()
 	}
@@ -113,6 +137,8 @@ func (m *Monitor) ExtendTimer() {
 type FileWriter struct {
 	path  string
 	wpath string
+	cpath string
+	ppath string
 }
 
 func NewFileWriter() *FileWriter {
@@ -125,6 +151,8 @@ func NewFileWriter() *FileWriter {
 
 	file.path = filepath.FromSlash(dir + "/throwpro.txt")
 	file.wpath = filepath.FromSlash(dir + "/.throwpro.txt")
+	file.cpath = filepath.FromSlash(dir + "/throwpro_calibration.json")
+	file.ppath = filepath.FromSlash(dir + "/throwpro_params.json")
 	log.Println("writing to", file.wpath)
 	return file
 }
@@ -161,19 +189,45 @@ type Display struct {
 	top    *widget.Label
 	bottom *widget.Label
 	debug  func(error)
+	trace  func(*throwlib.Trace)
 
 	window fyne.Window
 	f      *FileWriter
 
+	// the last throws as read, kept until the stronghold is found
+	throws      []throwlib.Throw
+	calibration *throwlib.Calibration
+
+	// a retuned parameter bundle, when there is one
+	params *throwlib.ParamsWatcher
+
 	Options struct {
 		OfflineMode bool
 		CrackedMode bool
+		Version     string
+		FOV         float64
+		Sensitivity *float64
+		Selection   string
+		Trace       bool
 	}
 }
 
 func NewDisplay(f *FileWriter) *Display {
 	d := new(Display)
 	d.f = f
+	cal, err := throwlib.LoadCalibration(f.cpath)
+	if err != nil {
+		log.Println("error loading calibration", err.Error())
+		cal = &throwlib.Calibration{}
+	}
+	d.calibration = cal
+	source := os.Getenv("THROWPRO_PARAMS")
+	if _, err := os.Stat(f.ppath); source == "" && err == nil {
+		source = f.ppath
+	}
+	if source != "" {
+		d.params = &throwlib.ParamsWatcher{Source: source, Every: time.Minute}
+	}
 
 	log.Println("creating UI")
 	a := app.New()
@@ -214,6 +268,14 @@ func NewDisplay(f *FileWriter) *Display {
 		}
 		debugUI.SetText("Error: " + e.Error())
 	}
+	d.trace = func(tr *throwlib.Trace) {
+		if tr == nil {
+			return
+		}
+		b, _ := json.Marshal(tr)
+		log.Println("trace", string(b))
+		debugUI.SetText("Trace: " + tr.Summary())
+	}
 
 	var toggle func()
 	showButton := widget.NewButton("Open Help Window", func() { toggle() })
@@ -222,7 +284,8 @@ func NewDisplay(f *FileWriter) *Display {
 	w.SetIcon(fyne.NewStaticResource("eye.png", iconData))
 
 	toggle = func() {
-		throwlib.DEBUG = true
+		// trace solves while the help window can show them
+		d.Options.Trace = true
 		help.Show()
 		return
 	}
@@ -230,7 +293,48 @@ func NewDisplay(f *FileWriter) *Display {
 	w.SetContent(widget.NewVBox(mainUI, secondUI, showButton))
 	cracked := widget.NewCheck("Cracked Mode", func(b bool) { d.Options.CrackedMode = b })
 	online := widget.NewCheck("Offline Mode", func(b bool) { d.Options.OfflineMode = b })
-	opts := widget.NewHBox(cracked, online)
+	version := widget.NewSelect(throwlib.ProfileNames(), func(v string) { d.Options.Version = v })
+	version.SetSelected(throwlib.DefaultProfile.Name)
+	selection := widget.NewSelect(throwlib.SelectorNames(), func(v string) { d.Options.Selection = v })
+	selection.SetSelected(throwlib.ActiveParams().SelectionMethod)
+	fov := widget.NewEntry()
+	fov.SetPlaceHolder("FOV")
+	fov.OnChanged = func(v string) {
+		d.Options.FOV = 0
+		if v == "" {
+			return
+		}
+		degrees, err := strconv.ParseFloat(v, 64)
+		if err != nil || degrees < 30 || degrees > 110 {
+			d.debug(fmt.Errorf("fov %q is not between 30 and 110 degrees, using %d", v, throwlib.AIM_FOV_DEFAULT))
+			return
+		}
+		d.Options.FOV = degrees
+	}
+	sens := widget.NewEntry()
+	sens.SetPlaceHolder("Sensitivity %")
+	sens.OnChanged = func(v string) {
+		d.Options.Sensitivity = nil
+		if pct, err := strconv.ParseFloat(strings.TrimSuffix(v, "%"), 64); err == nil {
+			// options.txt stores 100% as 0.5
+			setting := pct / 200
+			d.Options.Sensitivity = &setting
+		}
+	}
+	found := widget.NewButton("Found Stronghold", func() { d.debug(d.Confirm()) })
+	reload := widget.NewButton("Reload Parameters", func() {
+		if d.params == nil {
+			d.debug(fmt.Errorf("no parameters at %s", f.ppath))
+			return
+		}
+		p, err := d.params.Reload()
+		if err != nil {
+			d.debug(err)
+			return
+		}
+		debugUI.SetText("Using parameters " + p.Version)
+	})
+	opts := widget.NewHBox(cracked, online, version, selection, fov, sens, found, reload)
 	help.SetContent(widget.NewVBox(infoUI, debugUI, opts))
 	infoUI.SetText(BLURB)
 
@@ -250,7 +354,44 @@ func (d *Display) Stop() {
 	}
 }
 
+// Confirm records the last throws against the stronghold the player is
+// standing in, read from an F3+C on the clipboard. Eyes point at the starter
+// staircase, so the clip has to be taken there to be in the chunk they aim at.
+func (d *Display) Confirm() error {
+	text, err := clipboard.ReadAll()
+	if err != nil {
+		return err
+	}
+	at, err := throwlib.NewThrowFromString(text)
+	if err != nil {
+		return err
+	}
+	throws := []throwlib.Throw{}
+	for _, t := range d.throws {
+		// the clip from inside the stronghold was picked up as a throw too
+		if t.Type == throwlib.Overworld && !t.Similar(at) {
+			throws = append(throws, t)
+		}
+	}
+	if at.Type == throwlib.Nether || len(throws) == 0 {
+		return fmt.Errorf("press F3+C on the stronghold's starter staircase after throwing")
+	}
+	d.calibration.Add(throwlib.Outcome{Throws: throws, Stronghold: throwlib.ChunkFromPosition(at.X, at.Y)})
+	if err := d.calibration.Save(d.f.cpath); err != nil {
+		return err
+	}
+	d.throws = nil
+	log.Println("calibrating from", len(d.calibration.Outcomes), "outcomes")
+	return nil
+}
+
 func (d *Display) Refresh(res throwlib.Response) {
+	d.throws = nil
+	for _, diag := range res.Diagnostics {
+		if diag.Throw != nil && diag.Reason == "" {
+			d.throws = append(d.throws, *diag.Throw)
+		}
+	}
 	x, y := res.Coords[0], res.Coords[1]
 	px, py := res.Player[0], res.Player[1]
 
@@ -259,12 +400,17 @@ func (d *Display) Refresh(res throwlib.Response) {
 	confStr := fmt.Sprintf(`%.1f%%`, float64(res.Confidence)/10)
 	coords := fmt.Sprintf(`%d,%d`, x, y)
 	nether := fmt.Sprintf(`%d,%d`, x/8, y/8)
+	uncertainty := ""
+	if res.Uncertainty != nil {
+		uncertainty = fmt.Sprintf(` ±%.0f`, res.Uncertainty.Radius)
+	}
 
 	replacer := strings.NewReplacer(
 		`{distance}`, distStr,
 		`{confidence}`, confStr,
 		`{coords}`, coords,
 		`{nether}`, nether,
+		`{uncertainty}`, uncertainty,
 		`{line}`, "\n",
 	)
 	status := replacer.Replace(FORMATS[res.Method])
@@ -273,7 +419,35 @@ func (d *Display) Refresh(res throwlib.Response) {
 		mode = fmt.Sprintf("Portal Location: %d,%d", portal[0], portal[1])
 		status += fmt.Sprintf("\nportal location: %d,%d", portal[0], portal[1])
 	}
+	for _, alt := range res.Candidates {
+		if alt.Chosen {
+			continue
+		}
+		status += fmt.Sprintf("\nrunner-up: %d,%d (%.0f%%)", alt.Coords[0], alt.Coords[1], alt.Share*100)
+		break
+	}
+	if advice := res.Advice; advice != nil && res.Method == "educated" {
+		status += fmt.Sprintf("\nnext: walk %d blocks facing %.0f", advice.Blocks, advice.Yaw)
+	}
+	if res.Crossed && len(res.Hypotheses) > 1 {
+		prev := res.Hypotheses[len(res.Hypotheses)-2]
+		status += fmt.Sprintf("\nnew stronghold, previous: %d,%d", prev.Coords[0], prev.Coords[1])
+		mode = "Crossed into another stronghold's territory"
+	}
+	for n, diag := range res.Diagnostics {
+		if diag.Residual == nil {
+			continue
+		}
+		if diag.Reason == throwlib.ReasonRejected {
+			mode = fmt.Sprintf("Throw %d looks off by %.1f°", n+1, math.Abs(*diag.Residual))
+		}
+	}
+
+	if res.Partial {
+		mode += " (partial, ran out of time)"
+	}
 
+	d.trace(res.Trace)
 	log.Println("updating ui...", status, mode)
 	d.top.SetText(status)
 	d.bottom.SetText(mode)
@@ -282,6 +456,14 @@ func (d *Display) Refresh(res throwlib.Response) {
 	}
 }
 
+// Fail shows why no guess could be made, keeping the help for what to do next.
+func (d *Display) Fail(err *throwlib.Error) {
+	log.Println("no guess:", err.Code, err.Message)
+	d.top.SetText("No guess: " + err.Message)
+	d.bottom.SetText("Look at ender eye and press F3+C.")
+	d.debug(err)
+}
+
 func (d *Display) Reset() {
 	d.top.SetText(name)
 	d.bottom.SetText("Look at ender eye and press F3+C.")
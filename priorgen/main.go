@@ -0,0 +1,24 @@
+package main
+
+import (
+	"flag"
+	"io/ioutil"
+	"log"
+	"math/rand"
+
+	"github.com/dantoye/throwpro/throwlib"
+)
+
+func main() {
+	out := flag.String("o", "nearest_table.go", "file to write the table to")
+	seeds := flag.Int("seeds", 4000, "number of worlds to simulate")
+	samples := flag.Int("samples", 4, "players per distance bucket per world")
+	flag.Parse()
+
+	log.Println("simulating", *seeds, "worlds")
+	nt := throwlib.BuildNearestTable(&throwlib.Java116, *seeds, *samples, rand.New(rand.NewSource(1)))
+	if err := ioutil.WriteFile(*out, nt.GoSource("throwlib", "nearestTable"), 0644); err != nil {
+		log.Fatal(err.Error())
+	}
+	log.Println("wrote", len(nt.Data), "buckets to", *out)
+}
@@ -0,0 +1,72 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/dantoye/throwpro/throwlib"
+)
+
+func main() {
+	seedPath := flag.String("seeds", "seeds.txt", "file with one seed per line")
+	clipPath := flag.String("clips", "", "file with one /execute clip per line, default stdin")
+	version := flag.String("version", throwlib.JavaCurrent.Name, "minecraft version profile, 1.19 or later")
+	top := flag.Int("top", 10, "number of seeds to print")
+	asJSON := flag.Bool("json", false, "print matches as json")
+	flag.Parse()
+
+	profile, ok := throwlib.Profiles[*version]
+	if !ok {
+		log.Fatalf("unknown version %q, expected one of %s", *version, strings.Join(throwlib.ProfileNames(), ", "))
+	}
+
+	seedFile, err := os.Open(*seedPath)
+	if err != nil {
+		log.Fatal(err.Error())
+	}
+	seeds, err := throwlib.ReadSeeds(seedFile)
+	seedFile.Close()
+	if err != nil {
+		log.Fatal(err.Error())
+	}
+
+	clips := os.Stdin
+	if *clipPath != "" {
+		if clips, err = os.Open(*clipPath); err != nil {
+			log.Fatal(err.Error())
+		}
+		defer clips.Close()
+	}
+	throws := []throwlib.Throw{}
+	scanner := bufio.NewScanner(clips)
+	for scanner.Scan() {
+		text := strings.TrimSpace(scanner.Text())
+		if !strings.HasPrefix(text, "/execute") {
+			continue
+		}
+		t, err := throwlib.NewThrowFromString(text)
+		if err != nil {
+			log.Println("skipping an invalid clip:", err.Error())
+			continue
+		}
+		throws = append(throws, t)
+	}
+
+	matches, err := profile.RankSeeds(seeds, throws, *top)
+	if err != nil {
+		log.Fatal(err.Error())
+	}
+	if *asJSON {
+		enc, _ := json.MarshalIndent(matches, "", "\t")
+		fmt.Println(string(enc))
+		return
+	}
+	for _, m := range matches {
+		fmt.Printf("%d\tstronghold %d,%d\toff by %.2f°\n", m.Seed, m.Coords[0], m.Coords[1], m.Error)
+	}
+}
@@ -0,0 +1,68 @@
+package throwlib
+
+import "math"
+
+// how many ring widths the 95% fit may span before the throws only narrow
+// down a direction, like a single eye would
+const ADAPT_ONE_EYE = 0.5
+
+// below this many blocks the fit is sharp enough for cracked precision
+const ADAPT_HYPER = 48
+
+// how many of HyperSet's preferred angles every throw must sit within of the
+// fit, since the hyper layers reject anything read less precisely
+const ADAPT_HYPER_PREF = 6
+
+// adaptiveSet picks layers from how well the aimed throws pin down a point.
+// Rays from nearly the same spot, or nearly parallel ones, leave a fit that
+// stretches far along the ring, so they are scored like one throw. Hyper
+// needs three throws, as two rays always meet exactly and say nothing of how
+// precisely they were read.
+func (s *Session) adaptiveSet() LayerSet {
+	aimed := []Throw{}
+	for _, t := range s.Throws {
+		if t.Type == Overworld {
+			aimed = append(aimed, t)
+		}
+	}
+	if len(aimed) == 0 {
+		return s.params().Set(ZeroEyeSet.Code)
+	}
+
+	fit, err := Triangulate(aimed, s.Options.Aim.Sigma())
+	if err != nil {
+		s.Options.Trace.Logf("adaptive: no fit, %s", err.Error())
+		return s.params().Set(OneEyeSet.Code)
+	}
+
+	p := s.profile()
+	ring := p.ThrowRing(aimed[len(aimed)-1])
+	if r := p.RingID(ChunkFromPosition(fit.X, fit.Y)); r != -1 {
+		ring = r
+	}
+	if ring >= len(p.Rings) {
+		ring = len(p.Rings) - 1
+	}
+	width := float64(p.Rings[ring][1] - p.Rings[ring][0])
+
+	radius := fit.Ellipse.Radius
+	s.Options.Trace.Logf("adaptive: fit %.0f,%.0f radius %.0f ring %d", fit.X, fit.Y, radius, ring)
+	switch {
+	case radius > width*ADAPT_ONE_EYE:
+		return s.params().Set(OneEyeSet.Code)
+	case radius < ADAPT_HYPER && len(aimed) >= 3 && s.precise(aimed, fit.Residuals):
+		return s.params().Set(HyperSet.Code)
+	}
+	return s.params().Set(TwoEyeSet.Code)
+}
+
+func (s *Session) precise(aimed []Throw, residuals []float64) bool {
+	hyper := s.params().Set(HyperSet.Code)
+	for n, r := range residuals {
+		pref := aimed[n].Spread(hyper.AnglePref * s.Options.Aim.Scale())
+		if math.Abs(r) > pref*ADAPT_HYPER_PREF {
+			return false
+		}
+	}
+	return true
+}
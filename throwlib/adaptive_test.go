@@ -0,0 +1,33 @@
+package throwlib
+
+import "testing"
+
+func TestAdaptiveSet(t *testing.T) {
+	tx, ty := DefaultProfile.TargetOf(ChunkFromPosition(1200, 1600))
+	target := func(x, y float64) Throw { return throwAt(x, y, float64(tx), float64(ty)) }
+
+	cases := []struct {
+		name   string
+		throws []Throw
+		want   string
+	}{
+		{"same spot", []Throw{target(0, 0), target(10, 0)}, OneEyeSet.Code},
+		{"spread out", []Throw{target(0, 0), target(600, 0)}, TwoEyeSet.Code},
+		{"precise", []Throw{target(0, 0), target(600, 0), target(300, 400)}, HyperSet.Code},
+		{"sloppy", []Throw{target(0, 0), target(600, 0), throwAt(300, 400, float64(tx)+30, float64(ty))}, TwoEyeSet.Code},
+	}
+	for _, c := range cases {
+		s := NewSession()
+		s.Throws = c.throws
+		if got := s.CalcLayerSet().Code; got != c.want {
+			t.Errorf("%s: expected %s, got %s", c.name, c.want, got)
+		}
+	}
+
+	s := NewSession()
+	s.Options.Hyper = true
+	s.Throws = cases[0].throws
+	if got := s.CalcLayerSet().Code; got != HyperSet.Code {
+		t.Errorf("hyper option should still force %s, got %s", HyperSet.Code, got)
+	}
+}
@@ -0,0 +1,131 @@
+package throwlib
+
+import (
+	"math"
+	"sort"
+)
+
+const ADVICE_CHUNKS = 80
+
+// walking further always narrows the guess a little, so each block walked
+// costs this many blocks of spread
+const ADVICE_WALK_COST = 0.1
+
+var adviceDistances = []float64{50, 100, 200, 300, 500, 800}
+
+// Advice is where to walk before throwing again. Yaw is the facing to walk
+// in, in Minecraft degrees, and Spread is the expected radius in blocks of
+// the guess after throwing from Position.
+type Advice struct {
+	Position [2]int  `json:"position"`
+	Yaw      float64 `json:"yaw"`
+	Blocks   int     `json:"blocks"`
+	Spread   float64 `json:"spread"`
+}
+
+// weights is the current belief over chunks, normalized to sum to 1.
+func (s *Session) weights() map[Chunk]float64 {
+	if len(s.Posterior) > 0 && s.Options.Estimator == EstimatorPosterior {
+		return s.Posterior
+	}
+	w := make(map[Chunk]float64, len(s.Scores))
+	total := 0.0
+	for c, score := range s.Scores {
+		w[c] = float64(score)
+		total += float64(score)
+	}
+	for c := range w {
+		w[c] /= total
+	}
+	return w
+}
+
+// NextThrow picks a spot perpendicular to the last throw that minimises the
+// expected spread of the belief after one more throw from there, plus the
+// walk to get there.
+func (s *Session) NextThrow() (Advice, bool) {
+	if len(s.Throws) == 0 {
+		return Advice{}, false
+	}
+	last := s.Throws[len(s.Throws)-1]
+	if last.Type != Overworld {
+		return Advice{}, false
+	}
+
+	w := s.weights()
+	chunks := make([]Chunk, 0, len(w))
+	for c := range w {
+		chunks = append(chunks, c)
+	}
+	if len(chunks) < 2 {
+		return Advice{}, false
+	}
+	sort.Slice(chunks, func(i, j int) bool {
+		if w[chunks[i]] == w[chunks[j]] {
+			if chunks[i][0] == chunks[j][0] {
+				return chunks[i][1] < chunks[j][1]
+			}
+			return chunks[i][0] < chunks[j][0]
+		}
+		return w[chunks[i]] > w[chunks[j]]
+	})
+	if len(chunks) > ADVICE_CHUNKS {
+		chunks = chunks[:ADVICE_CHUNKS]
+	}
+
+	sigma := s.Options.Aim.Sigma()
+	best := Advice{}
+	lowest := math.Inf(1)
+	for _, d := range adviceDistances {
+		for _, side := range []float64{-1, 1} {
+			yaw := wrapRads(last.A + side*math.Pi/2)
+			x, y := last.X-math.Sin(yaw)*d, last.Y+math.Cos(yaw)*d
+			spread := expectedSpread(chunks, w, x, y, sigma)
+			if cost := spread + d*ADVICE_WALK_COST; cost < lowest {
+				lowest = cost
+				best = Advice{
+					Position: [2]int{int(x), int(y)},
+					Yaw:      degsFromRads(yaw),
+					Blocks:   int(d),
+					Spread:   spread,
+				}
+			}
+		}
+	}
+	return best, true
+}
+
+// expectedSpread averages, over where the stronghold may truly be, how spread
+// out the belief would be after a throw from x, y.
+func expectedSpread(chunks []Chunk, w map[Chunk]float64, x, y, sigma float64) float64 {
+	angles := make([]float64, len(chunks))
+	for i, c := range chunks {
+		cx, cy := c.Center()
+		angles[i] = math.Atan2(x-float64(cx), float64(cy)-y)
+	}
+
+	expected, norm := 0.0, 0.0
+	for i, truth := range chunks {
+		total, mx, my := 0.0, 0.0, 0.0
+		post := make([]float64, len(chunks))
+		for j, c := range chunks {
+			delta := wrapRads(angles[j]-angles[i]) / sigma
+			post[j] = w[c] * math.Exp(-0.5*delta*delta)
+			total += post[j]
+			cx, cy := c.Center()
+			mx += post[j] * float64(cx)
+			my += post[j] * float64(cy)
+		}
+		mx /= total
+		my /= total
+		variance := 0.0
+		for j, c := range chunks {
+			cx, cy := c.Center()
+			dx, dy := float64(cx)-mx, float64(cy)-my
+			variance += post[j] / total * (dx*dx + dy*dy)
+		}
+		expected += w[truth] * math.Sqrt(variance)
+		norm += w[truth]
+	}
+	return expected / norm
+}
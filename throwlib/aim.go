@@ -0,0 +1,58 @@
+package throwlib
+
+import "math"
+
+// screen height the pixel error is measured on
+const AIM_SCREEN_HEIGHT = 1080
+
+// options.txt stores fov as how far it is from the default, in units of the
+// range either side of it
+const AIM_FOV_DEFAULT = 70
+const AIM_FOV_RANGE = 40
+
+// Aim describes how precisely a player lines the crosshair up with an eye.
+// FOV is the vertical field of view in degrees, Sensitivity the mouse
+// sensitivity as stored in options.txt where 0.5 shows as 100%, and
+// PixelError how many screen pixels off the crosshair usually ends up. Noise
+// is a yaw error in degrees measured from past throws, and wins over the rest.
+type Aim struct {
+	FOV         float64 `json:"fov"`
+	Sensitivity float64 `json:"sensitivity"`
+	PixelError  float64 `json:"pixel_error"`
+	Noise       float64 `json:"noise,omitempty"`
+}
+
+// DefaultAim is the setup the angle thresholds were tuned with.
+var DefaultAim = Aim{FOV: 70, Sensitivity: 0.5, PixelError: 1}
+
+// TurnStep is how far in degrees the view turns for the smallest mouse move.
+func (a *Aim) TurnStep() float64 {
+	f := a.Sensitivity*0.6 + 0.2
+	return f * f * f * 8 * 0.15
+}
+
+// YawError is the expected yaw error in degrees: the angle the pixel error
+// covers at the center of the screen, plus rounding to the nearest turn step.
+func (a *Aim) YawError() float64 {
+	pixel := degsFromRads(math.Atan(2 * math.Tan(radsFromDegs(a.FOV)/2) / AIM_SCREEN_HEIGHT))
+	read := pixel * a.PixelError
+	turn := a.TurnStep() / math.Sqrt(12)
+	return math.Sqrt(read*read + turn*turn)
+}
+
+// Scale is how much larger the yaw error is than with DefaultAim. A nil aim
+// is the default.
+func (a *Aim) Scale() float64 {
+	if a == nil {
+		return 1
+	}
+	if a.Noise > 0 {
+		return a.Noise / YAW_SIGMA
+	}
+	return a.YawError() / DefaultAim.YawError()
+}
+
+// Sigma is the yaw uncertainty of one eye in radians.
+func (a *Aim) Sigma() float64 {
+	return radsFromDegs(YAW_SIGMA) * a.Scale()
+}
@@ -0,0 +1,55 @@
+package throwlib
+
+import (
+	"math"
+	"testing"
+)
+
+func TestAimScale(t *testing.T) {
+	if s := DefaultAim.Scale(); math.Abs(s-1) > 1e-9 {
+		t.Errorf("default aim should not scale, got %.3f", s)
+	}
+	var none *Aim
+	if none.Scale() != 1 || DefaultParams.MaxAngle(none) != radsFromDegs(MAX_EYE_ANGLE) {
+		t.Errorf("no aim should behave like the default")
+	}
+	if step := DefaultAim.TurnStep(); math.Abs(step-0.15) > 1e-9 {
+		t.Errorf("expected 100%% sensitivity to turn 0.15 degrees, got %.4f", step)
+	}
+
+	narrow := Aim{FOV: 30, Sensitivity: 0.5, PixelError: 1}
+	if narrow.Scale() >= 1 {
+		t.Errorf("a narrow fov should read eyes more precisely, got scale %.3f", narrow.Scale())
+	}
+	if DefaultParams.MaxAngle(&narrow) != radsFromDegs(MAX_EYE_ANGLE) {
+		t.Errorf("a narrow fov should not tighten rejection")
+	}
+
+	wide := Aim{FOV: 110, Sensitivity: 1, PixelError: 3}
+	if wide.Scale() <= 1 || DefaultParams.MaxAngle(&wide) <= radsFromDegs(MAX_EYE_ANGLE) {
+		t.Errorf("a wide fov should loosen rejection, got scale %.3f", wide.Scale())
+	}
+}
+
+func TestRequestAim(t *testing.T) {
+	req := Request{}
+	if req.aim() != nil {
+		t.Errorf("expected no aim when the request leaves it out")
+	}
+	zero := 0.0
+	req.Options.FOV = 30
+	req.Options.Sensitivity = &zero
+	aim := req.aim()
+	if aim == nil || aim.FOV != 30 || aim.Sensitivity != 0 || aim.PixelError != DefaultAim.PixelError {
+		t.Errorf("unexpected aim %#v", aim)
+	}
+	// options.txt stores 30 degrees as -1
+	req.Options.FOV = -1
+	if aim := req.aim(); aim.FOV != 30 {
+		t.Errorf("expected fov -1 from options.txt to be 30 degrees, got %v", aim.FOV)
+	}
+	req.Options.FOV = 0.5
+	if aim := req.aim(); aim.FOV != 90 {
+		t.Errorf("expected fov 0.5 from options.txt to be 90 degrees, got %v", aim.FOV)
+	}
+}
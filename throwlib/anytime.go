@@ -0,0 +1,141 @@
+package throwlib
+
+import (
+	"context"
+	"math"
+	"time"
+)
+
+const EstimatorCoarse = "coarse"
+
+// how many chunks are scored between checks for the deadline
+const SCORE_CHECK_EVERY = 256
+
+// stopped is whether the solve has run out of time, and should settle for
+// the best guess so far.
+func (s *Session) stopped() bool {
+	return s.ctx != nil && done(s.ctx) != nil
+}
+
+// done is the context's error, or a deadline error once its deadline has
+// passed. Busy scoring can hold off the timer that would cancel the context,
+// so the deadline is checked here too.
+func done(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if deadline, ok := ctx.Deadline(); ok && !time.Now().Before(deadline) {
+		return context.DeadlineExceeded
+	}
+	return nil
+}
+
+func (s *Session) context() context.Context {
+	if s.ctx == nil {
+		return context.Background()
+	}
+	return s.ctx
+}
+
+func (s *Session) progress(g Guess) {
+	if s.Options.Progress != nil {
+		s.Options.Progress(g)
+	}
+}
+
+// BestGuessContext is BestGuess, refining from an instant coarse guess for as
+// long as the context allows. A guess cut short is marked Partial.
+func (s *Session) BestGuessContext(ctx context.Context, ts ...Throw) (Guess, error) {
+	if len(ts) == 0 {
+		return Guess{}, ErrNoThrows
+	}
+	s.ctx = ctx
+	defer s.Options.Trace.total(time.Now())
+	coarse := s.coarseGuess(ts)
+	s.progress(coarse)
+	if s.stopped() {
+		return coarse, nil
+	}
+
+	var g Guess
+	var err error
+	seeded := false
+	if s.Options.Seed != nil {
+		g, seeded = s.SeedGuess(*s.Options.Seed, ts)
+		if !seeded {
+			s.Options.Trace.Logf("throws agree with no stronghold of seed %d", *s.Options.Seed)
+		}
+	}
+	if !seeded {
+		if g, err = s.bestGuess(ts...); err != nil {
+			if !s.stopped() {
+				return Guess{}, err
+			}
+			s.Options.Trace.Logf("out of time before any guess, keeping the coarse one")
+			return coarse, nil
+		}
+	}
+	if fit, err := Triangulate(g.Used, s.Options.Aim.Sigma()); err == nil {
+		g.Fit = &fit
+	}
+	if !g.Partial {
+		s.progress(g)
+	}
+	return g, nil
+}
+
+// coarseGuess is where the aimed throws meet, or otherwise a spot along the
+// last throw as far out as the middle of its ring. It takes no scoring, so
+// there is always a guess to fall back on.
+func (s *Session) coarseGuess(ts []Throw) Guess {
+	s.Throws = ts
+	p := s.profile()
+	aimed := []Throw{}
+	for _, t := range ts {
+		if t.Type == Overworld {
+			aimed = append(aimed, t)
+		}
+	}
+
+	fit, err := Triangulate(aimed, s.Options.Aim.Sigma())
+	x, y := fit.X, fit.Y
+	if err != nil || p.RingID(ChunkFromPosition(x, y)) == -1 {
+		t := ts[len(ts)-1]
+		ring := p.ThrowRing(t)
+		if ring >= len(p.Rings) {
+			ring = len(p.Rings) - 1
+		}
+		mid := float64(p.Rings[ring][0]+p.Rings[ring][1]) / 2
+		x, y = towardsRing(t, mid)
+	}
+	return Guess{
+		Chunk:     ChunkFromPosition(x, y),
+		Method:    s.Layers().Code,
+		Estimator: EstimatorCoarse,
+		Used:      ts,
+		Partial:   true,
+	}
+}
+
+// towardsRing is where a throw first reaches the given distance from the
+// origin, or for a blind throw, that far out in the player's direction.
+func towardsRing(t Throw, radius float64) (float64, float64) {
+	if t.Type != Overworld {
+		d := dist(t.X, t.Y, 0, 0)
+		if d < 1 {
+			return 0, radius
+		}
+		return t.X / d * radius, t.Y / d * radius
+	}
+	dx, dy := -math.Sin(t.A), math.Cos(t.A)
+	b := t.X*dx + t.Y*dy
+	disc := b*b - (t.X*t.X + t.Y*t.Y) + radius*radius
+	k := -b
+	if disc > 0 {
+		k += math.Sqrt(disc)
+	}
+	if k < 0 {
+		k = 0
+	}
+	return t.X + k*dx, t.Y + k*dy
+}
@@ -0,0 +1,91 @@
+package throwlib
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestCoarseGuess(t *testing.T) {
+	for _, test := range progressionTests {
+		sess := NewSession()
+		g := sess.coarseGuess(test.throws[:1])
+		if g.Estimator != EstimatorCoarse || !g.Partial {
+			t.Errorf("expected a partial coarse guess, got %#v", g)
+		}
+		if sess.profile().RingID(g.Chunk) == -1 {
+			t.Errorf("coarse guess %v is outside the rings", g.Chunk)
+		}
+	}
+}
+
+func TestCancelledGuess(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	test := progressionTests[0]
+	g, err := NewSession().BestGuessContext(ctx, test.throws...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !g.Partial || g.Estimator != EstimatorCoarse {
+		t.Errorf("expected the coarse guess when out of time, got %#v", g)
+	}
+}
+
+func TestDeadlineGuess(t *testing.T) {
+	var longest []Throw
+	for _, test := range progressionTests {
+		if len(test.throws) > len(longest) {
+			longest = test.throws
+		}
+	}
+	start := time.Now()
+	full := guessOf(NewSession(), longest...)
+	took := time.Since(start)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond)
+	defer cancel()
+	start = time.Now()
+	g, err := NewSession().BestGuessContext(ctx, longest...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !g.Partial {
+		t.Errorf("expected a partial guess within 1ms, a full one took %v", took)
+	}
+	if stopped := time.Since(start); stopped > took/2 {
+		t.Errorf("expected the guess to stop near its deadline, took %v of %v", stopped, took)
+	}
+	t.Logf("full guess %v in %v, partial %v (%s)", full.Chunk, took, g.Chunk, g.Estimator)
+}
+
+func TestGuessProgress(t *testing.T) {
+	test := progressionTests[0]
+	sess := NewSession()
+	guesses := []Guess{}
+	sess.Options.Progress = func(g Guess) { guesses = append(guesses, g) }
+	g := guessOf(sess, test.throws[:2]...)
+	if len(guesses) < 2 || guesses[0].Estimator != EstimatorCoarse {
+		t.Fatalf("expected a coarse guess first, got %d guesses", len(guesses))
+	}
+	last := guesses[len(guesses)-1]
+	if g.Partial || last.Partial || last.Chunk != g.Chunk {
+		t.Errorf("expected the finished guess %v last, got %v", g.Chunk, last.Chunk)
+	}
+}
+
+func TestCancelledResponse(t *testing.T) {
+	req := Request{Clips: []string{
+		"/execute in minecraft:overworld run tp @s 294.96 116.93 -486.85 -499.05 -25.35",
+		"/execute in minecraft:overworld run tp @s 362.90 116.93 -669.03 -493.95 -31.65",
+	}}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	res, err := NewResponseContext(ctx, req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !res.Partial || res.Chunk == nil || res.Advice != nil {
+		t.Errorf("expected a partial guess without advice, got %#v", res)
+	}
+}
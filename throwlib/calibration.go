@@ -0,0 +1,128 @@
+package throwlib
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"math"
+	"os"
+)
+
+// how many confirmed outcomes a calibration keeps, dropping the oldest
+const CALIBRATION_OUTCOMES = 30
+
+// how many throws a correction is pulled back towards no bias by, so a
+// couple of unlucky throws cannot skew every later guess
+const CALIBRATION_PRIOR = 5
+
+// throws further off than this were misclicks, not a reading habit
+const CALIBRATION_OUTLIER = 2 * MAX_EYE_ANGLE
+
+// Outcome is a finished search: the throws as they were read, and the chunk
+// of the stronghold's starter staircase, which is the chunk eyes point at.
+// Other rooms of the stronghold can be chunks away from it.
+type Outcome struct {
+	Throws     []Throw `json:"throws"`
+	Stronghold Chunk   `json:"stronghold"`
+}
+
+// Calibration is what a player has confirmed so far, to learn how they read eyes.
+type Calibration struct {
+	Outcomes []Outcome `json:"outcomes"`
+}
+
+// Correction is a player's habitual yaw bias and noise, in degrees.
+type Correction struct {
+	Bias    float64 `json:"bias"`
+	Noise   float64 `json:"noise"`
+	Samples int     `json:"samples"`
+}
+
+func LoadCalibration(path string) (*Calibration, error) {
+	cal := &Calibration{}
+	b, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return cal, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(b, cal); err != nil {
+		return nil, err
+	}
+	return cal, nil
+}
+
+func (c *Calibration) Save(path string) error {
+	b, err := json.MarshalIndent(c, "", "\t")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path, b, 0644)
+}
+
+func (c *Calibration) Add(o Outcome) {
+	c.Outcomes = append(c.Outcomes, o)
+	if len(c.Outcomes) > CALIBRATION_OUTCOMES {
+		c.Outcomes = c.Outcomes[len(c.Outcomes)-CALIBRATION_OUTCOMES:]
+	}
+}
+
+// Correction measures every aimed throw against where its stronghold was.
+func (c *Calibration) Correction(p *Profile) Correction {
+	residuals := []float64{}
+	for _, o := range c.Outcomes {
+		for _, t := range o.Throws {
+			if t.Type != Overworld {
+				continue
+			}
+			r := degsFromRads(p.Angle(o.Stronghold, t.A, t.X, t.Y))
+			if math.Abs(r) > CALIBRATION_OUTLIER {
+				continue
+			}
+			residuals = append(residuals, r)
+		}
+	}
+	n := float64(len(residuals))
+	if n == 0 {
+		return Correction{}
+	}
+
+	sum := 0.0
+	for _, r := range residuals {
+		sum += r
+	}
+	mean := sum / n
+	variance := 0.0
+	for _, r := range residuals {
+		variance += (r - mean) * (r - mean)
+	}
+	// pool with a prior of typical throws
+	variance = (variance + CALIBRATION_PRIOR*YAW_SIGMA*YAW_SIGMA) / (n + CALIBRATION_PRIOR)
+	return Correction{
+		Bias:    sum / (n + CALIBRATION_PRIOR),
+		Noise:   math.Sqrt(variance),
+		Samples: len(residuals),
+	}
+}
+
+// Correct takes the bias out of an aimed throw.
+func (c Correction) Correct(t Throw) Throw {
+	if t.Type != Overworld || c.Bias == 0 {
+		return t
+	}
+	t.A -= radsFromDegs(c.Bias)
+	return t
+}
+
+// Apply sets the measured noise on the aim, once there is some to go by.
+func (c Correction) Apply(aim *Aim) *Aim {
+	if c.Samples == 0 {
+		return aim
+	}
+	measured := DefaultAim
+	if aim != nil {
+		measured = *aim
+	}
+	measured.Noise = c.Noise
+	return &measured
+}
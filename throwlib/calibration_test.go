@@ -0,0 +1,98 @@
+package throwlib
+
+import (
+	"io/ioutil"
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func biasedOutcome(stronghold Chunk, bias float64, from ...[2]float64) Outcome {
+	o := Outcome{Stronghold: stronghold}
+	tx, ty := DefaultProfile.TargetOf(stronghold)
+	for _, f := range from {
+		t := throwAt(f[0], f[1], float64(tx), float64(ty))
+		t.A += radsFromDegs(bias)
+		o.Throws = append(o.Throws, t)
+	}
+	return o
+}
+
+func TestCalibrationCorrection(t *testing.T) {
+	cal := &Calibration{}
+	if c := cal.Correction(DefaultProfile); c != (Correction{}) {
+		t.Errorf("expected no correction without outcomes, got %#v", c)
+	}
+
+	for n := 0; n < 5; n++ {
+		o := biasedOutcome(ChunkFromPosition(0, 2000), 0.3, [2]float64{float64(n * 50), 100}, [2]float64{-400, float64(n * 80)})
+		// one misclick that must not drag the bias along
+		o.Throws = append(o.Throws, NewThrow(0, 0, 45))
+		cal.Add(o)
+	}
+	c := cal.Correction(DefaultProfile)
+	if c.Samples != 10 {
+		t.Fatalf("expected the 10 aimed throws to count, got %d", c.Samples)
+	}
+	if want := 0.3 * 10 / 15; math.Abs(c.Bias-want) > 1e-6 {
+		t.Errorf("expected a bias of %.3f, got %.3f", want, c.Bias)
+	}
+	if c.Noise >= YAW_SIGMA {
+		t.Errorf("consistent throws should measure below the default noise, got %.3f", c.Noise)
+	}
+
+	throw := NewThrow(10, 10, 30)
+	if got := c.Correct(throw).A; math.Abs(got-(throw.A-radsFromDegs(c.Bias))) > 1e-12 {
+		t.Errorf("throw was not corrected by the bias")
+	}
+	blind := NewBlindThrow(10, 10)
+	if c.Correct(blind) != blind {
+		t.Errorf("blind throws have no yaw to correct")
+	}
+	if aim := c.Apply(nil); aim == nil || aim.Scale() >= 1 {
+		t.Errorf("expected measured noise to tighten the aim")
+	}
+
+	req := Request{Clips: []string{"/execute in minecraft:overworld run tp @s 294.96 116.93 -486.85 -499.05 -25.35"}}
+	req.Options.Calibration = cal
+	res, err := NewResponse(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Correction == nil || *res.Correction != c {
+		t.Errorf("expected the response to report the correction, got %v", res.Correction)
+	}
+
+	for n := 0; n < CALIBRATION_OUTCOMES; n++ {
+		cal.Add(Outcome{})
+	}
+	if len(cal.Outcomes) != CALIBRATION_OUTCOMES {
+		t.Errorf("expected outcomes capped at %d, got %d", CALIBRATION_OUTCOMES, len(cal.Outcomes))
+	}
+}
+
+func TestCalibrationFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "throwpro")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "calibration.json")
+
+	cal, err := LoadCalibration(path)
+	if err != nil || len(cal.Outcomes) != 0 {
+		t.Fatalf("expected a missing file to load empty, got %v %v", cal, err)
+	}
+	cal.Add(biasedOutcome(ChunkFromPosition(0, 2000), 0.2, [2]float64{0, 100}))
+	if err := cal.Save(path); err != nil {
+		t.Fatal(err)
+	}
+	loaded, err := LoadCalibration(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(loaded.Outcomes) != 1 || loaded.Outcomes[0].Throws[0] != cal.Outcomes[0].Throws[0] {
+		t.Errorf("calibration did not survive a round trip: %#v", loaded)
+	}
+}
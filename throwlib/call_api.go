@@ -2,6 +2,7 @@ package throwlib
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
@@ -13,13 +14,22 @@ import (
 // public free use key
 const API_KEY = "b4chnoWQeR1pBtmDxslcTaReIdThofpR8QiUkiQ6"
 
-func postRequest(body []byte) (*Response, error) {
+// longest to wait on the API before guessing offline
+const API_TIMEOUT = 500 * time.Millisecond
+
+// most clips the API guesses from, more are guessed offline
+const API_MAX_CLIPS = 5
+
+func postRequest(ctx context.Context, body []byte) (*Response, error) {
 	url := `https://4f3fvniy4f.execute-api.us-east-1.amazonaws.com/dev/guess`
-	req, _ := http.NewRequest("POST", url, bytes.NewReader(body))
+	req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
 
 	req.Header.Add("x-api-key", API_KEY)
 
-	cli := http.Client{Timeout: 500 * time.Millisecond}
+	cli := http.Client{Timeout: API_TIMEOUT}
 	res, err := cli.Do(req)
 	if err != nil {
 		return nil, err
@@ -33,6 +43,9 @@ func postRequest(body []byte) (*Response, error) {
 	if err := json.Unmarshal(out, &iRes); err != nil {
 		return nil, err
 	}
+	if iRes.Error != nil {
+		return &iRes, iRes.Error
+	}
 	if res.StatusCode != 200 {
 		return nil, fmt.Errorf("error using online mode, status code %d", res.StatusCode)
 	}
@@ -40,24 +53,41 @@ func postRequest(body []byte) (*Response, error) {
 	return &iRes, nil
 }
 
-func PostRequest(req Request, offline bool) Response {
+// PostRequest guesses online, or on this computer when offline, when the API
+// cannot be reached or when there are more clips than it takes. A guess that
+// failed comes with an *Error.
+func PostRequest(req Request, offline bool) (Response, error) {
+	return PostRequestContext(context.Background(), req, offline)
+}
+
+// PostRequestContext is PostRequest within the context's deadline. Whatever
+// time the API leaves goes to guessing offline, which returns a partial guess
+// rather than nothing when it runs out.
+func PostRequestContext(ctx context.Context, req Request, offline bool) (Response, error) {
 	j, _ := json.Marshal(req)
 
+	if !offline && len(req.Clips) > API_MAX_CLIPS {
+		log.Println("guessing offline from", len(req.Clips), "clips, more than the API takes")
+		offline = true
+	}
 	if !offline {
-		res, err := postRequest(j)
-		if err != nil {
-			log.Println("error using online mode:", err.Error())
-		} else {
-			return *res
+		res, err := postRequest(ctx, j)
+		if err == nil {
+			return *res, nil
+		}
+		if e, failed := err.(*Error); failed && e.Code != ErrCodeTooManyClips {
+			// the API could not guess, so neither will this computer
+			return *res, err
 		}
+		log.Println("error using online mode:", err.Error())
 	}
 
 	iReq := Request{}
 	json.Unmarshal(j, &iReq)
-	iRes := NewResponse(iReq)
+	iRes, err := NewResponseContext(ctx, iReq)
 	out, _ := json.Marshal(iRes)
 
 	res := Response{}
 	json.Unmarshal(out, &res)
-	return res
+	return res, err
 }
@@ -1,8 +1,9 @@
 package throwlib
 
 import (
+	"context"
+	"errors"
 	"fmt"
-	"log"
 	"math"
 	"sort"
 	"time"
@@ -12,11 +13,9 @@ import (
 	"github.com/muesli/kmeans"
 )
 
-const SELECTION_METHOD = "closest" // or "highest"
+const SELECTION_METHOD = SelectClosest // or SelectHighest, SelectTravel
 
-var DEBUG = false
-
-var DEBUG_CHUNK Chunk
+const MAX_SUBSET_THROWS = 8
 
 type ChunkList []Chunk
 
@@ -32,14 +31,16 @@ func (t Chunk) Dist(x, y float64) float64 {
 	return dist(float64(ax), float64(ay), x, y)
 }
 
+// Staircase is where the default profile puts the starter staircase.
+//
+// Deprecated: use Profile.Staircase, which follows the requested version.
 func (c Chunk) Staircase() (int, int) {
-	x, y := c.Center()
-	return x - 4, y - 4
+	return DefaultProfile.Staircase(c)
 }
 
 func (c Chunk) String() string {
 	x, y := c.Center()
-	ring := RingID(c)
+	ring := DefaultProfile.RingID(c)
 	return fmt.Sprintf("chunk %d,%d \t(center %d, %d, ring %d)", c[0], c[1], x, y, ring)
 }
 
@@ -72,16 +73,16 @@ var throwNames = map[ThrowType]string{Overworld: "overworld", Blind: "blind", Ne
 type Throw struct {
 	X, Y, A float64
 	Type    ThrowType
+
+	// how many eyes thrown from the same spot were averaged into this one
+	Eyes int `json:",omitempty"`
 }
 
+// RingID is the ring of the default profile the throw was made in.
+//
+// Deprecated: use Profile.ThrowRing, which follows the requested version.
 func (t Throw) RingID() int {
-	dist := int(dist(t.X, t.Y, 0, 0))
-	for id, r := range rings {
-		if dist < r[1] {
-			return id
-		}
-	}
-	return len(rings)
+	return DefaultProfile.ThrowRing(t)
 }
 
 func NewThrowFromArray(arr [3]float64) Throw {
@@ -105,17 +106,101 @@ func (t Throw) Similar(other Throw) bool {
 	return false
 }
 
+func (t Throw) eyes() float64 {
+	if t.Eyes < 1 {
+		return 1
+	}
+	return float64(t.Eyes)
+}
+
+// Spread scales a single eye's yaw uncertainty down for the eyes averaged into t.
+func (t Throw) Spread(sigma float64) float64 {
+	return sigma / math.Sqrt(t.eyes())
+}
+
+// MergeThrows averages throws from the same spot into one, taking the circular
+// mean of their yaws.
+func MergeThrows(ts ...Throw) Throw {
+	merged := Throw{Type: ts[0].Type}
+	sin, cos, eyes := 0.0, 0.0, 0.0
+	for _, t := range ts {
+		w := t.eyes()
+		merged.X += t.X * w
+		merged.Y += t.Y * w
+		sin += math.Sin(t.A) * w
+		cos += math.Cos(t.A) * w
+		eyes += w
+	}
+	merged.X /= eyes
+	merged.Y /= eyes
+	merged.A = math.Atan2(sin, cos)
+	merged.Eyes = int(eyes)
+	return merged
+}
+
 type Guess struct {
-	Chunk      [2]int `json:"chunk"`
-	Method     string `json:"method"`
-	Confidence int    `json:"confidence"`
-	Used       []Throw
+	Chunk       [2]int  `json:"chunk"`
+	Method      string  `json:"method"`
+	Confidence  int     `json:"confidence"`
+	Probability float64 `json:"probability,omitempty"`
+	Estimator   string  `json:"estimator,omitempty"`
+	Selection   string  `json:"selection,omitempty"`
+	Used        []Throw
+	Rejected    []Throw
+	Candidates  []Candidate
+
+	// the oldest throws, left out of the search once there are more than
+	// MAX_SUBSET_THROWS, and also counted among the rejected
+	Dropped []Throw `json:"-"`
+
+	Fit *Triangulation `json:"fit,omitempty"`
+
+	// the solve ran out of time before it could finish
+	Partial bool `json:"partial,omitempty"`
 }
 
 func (g Guess) String() string {
 	return fmt.Sprintf(`%s %d %s `, g.Method, g.Confidence, Chunk(g.Chunk))
 }
 
+const MAX_CANDIDATES = 3
+
+// Candidate is one competing stronghold location, with Share being the part
+// of the total score its cluster holds. Chosen marks the cluster the guess
+// was picked from, whose center need not be the guessed chunk.
+type Candidate struct {
+	Chunk    [2]int  `json:"chunk"`
+	Coords   [2]int  `json:"coords"`
+	Share    float64 `json:"share"`
+	Distance float64 `json:"distance"`
+	Chosen   bool    `json:"chosen,omitempty"`
+}
+
+func NewCandidate(p *Profile, x, y float64, score, total int, t Throw) Candidate {
+	c := ChunkFromPosition(x, y)
+	sx, sy := p.Staircase(c)
+	return Candidate{
+		Chunk:    c,
+		Coords:   [2]int{sx, sy},
+		Share:    float64(score) / float64(total),
+		Distance: c.Dist(t.X, t.Y),
+	}
+}
+
+// RankCandidates orders candidates by share, keeping the top MAX_CANDIDATES.
+func RankCandidates(cs []Candidate) []Candidate {
+	sort.Slice(cs, func(i, j int) bool {
+		if cs[i].Share == cs[j].Share {
+			return cs[i].Distance < cs[j].Distance
+		}
+		return cs[i].Share > cs[j].Share
+	})
+	if len(cs) > MAX_CANDIDATES {
+		cs = cs[:MAX_CANDIDATES]
+	}
+	return cs
+}
+
 type ScoredChunk struct {
 	Chunk
 	Score int
@@ -133,24 +218,53 @@ func (c ScoredChunk) GetID() string {
 	return fmt.Sprintf(`%d,%d`, c.Chunk[0], c.Chunk[1])
 }
 
+// Session holds the throws and scores of the solve it is in the middle of, so
+// it must not be shared between goroutines. Use a Solver for that.
 type Session struct {
 	Throws      []Throw
 	CustomLayer *LayerSet
 	LayerSet    LayerSet
+	Profile     *Profile
+	Params      *Params
 
 	Scores     map[Chunk]int
 	TotalScore int
 
-	Options struct {
-		Hyper bool
-	}
+	Posterior map[Chunk]float64
+
+	Options SolveOptions
+
+	// stops a solve early, keeping the best guess so far
+	ctx context.Context
+}
+
+// SolveOptions is how throws are read and a guess picked. They are only read
+// during a solve, so many solves can share them.
+type SolveOptions struct {
+	Hyper     bool
+	Estimator string
+	Seed      *int64
+	Aim       *Aim
+
+	// how the guess is picked from the scores, or the params' method
+	Selector Selector
+
+	// records how the solve went, when set
+	Trace *Trace
+
+	// called with each better guess as a solve refines, starting with a
+	// coarse one, on the goroutine solving
+	Progress func(Guess)
+
+	// registered layers to weigh in on top of the chosen set
+	Layers map[string]int
 }
 
 func NewSession(cl ...LayerSet) *Session {
 	if len(cl) > 0 {
-		return &Session{CustomLayer: &cl[0]}
+		return &Session{CustomLayer: &cl[0], Params: ActiveParams()}
 	}
-	return &Session{}
+	return &Session{Params: ActiveParams()}
 }
 
 func (s *Session) Chunks() []Chunk {
@@ -175,79 +289,198 @@ func (s *Session) ByScore() []Chunk {
 	return chunks
 }
 
+func (s *Session) profile() *Profile {
+	if s.Profile == nil {
+		return DefaultProfile
+	}
+	return s.Profile
+}
+
+func (s *Session) params() *Params {
+	if s.Params == nil {
+		return ActiveParams()
+	}
+	return s.Params
+}
+
+func (s *Session) selector() Selector {
+	if s.Options.Selector != nil {
+		return s.Options.Selector
+	}
+	sel, err := SelectorByName(s.params().SelectionMethod)
+	if err != nil {
+		return ClosestSelector{}
+	}
+	return sel
+}
+
 func (s *Session) CalcLayerSet() LayerSet {
+	ls := s.calcLayerSet()
+	ls.Profile = s.profile()
+	ls.Aim = s.Options.Aim
+	ls.Params = s.params()
+	ls.Trace = s.Options.Trace
+	for name, weight := range s.Options.Layers {
+		ls = ls.WithLayer(name, weight)
+	}
+	return ls
+}
+
+func (s *Session) calcLayerSet() LayerSet {
 	if s.CustomLayer != nil {
 		return *s.CustomLayer
 	}
 	if len(s.Throws) == 1 {
 		if s.Throws[0].Type == Blind {
-			return ZeroEyeSet
+			return s.params().Set(ZeroEyeSet.Code)
 		}
-		return OneEyeSet
+		return s.params().Set(OneEyeSet.Code)
 	}
 	if s.Options.Hyper {
-		return HyperSet
+		return s.params().Set(HyperSet.Code)
 	}
-	return TwoEyeSet
+	return s.adaptiveSet()
 }
 
 func (s *Session) Layers() LayerSet {
 	appropriate := s.CalcLayerSet()
-	if s.LayerSet != appropriate {
+	if s.LayerSet.Code != appropriate.Code {
 		s.LayerSet = appropriate
-		if DEBUG {
-			log.Println("switching layer set", appropriate)
-		}
+		s.Options.Trace.Logf("switching layer set to %s", appropriate.Code)
 	}
 	return appropriate
 }
 
-func (s *Session) BestGuess(ts ...Throw) Guess {
-	if len(ts) == 0 {
-		panic("no throws")
+// BestGuess finds the stronghold the throws point at. The error is an *Error
+// when the throws cannot be scored at all.
+func (s *Session) BestGuess(ts ...Throw) (Guess, error) {
+	return s.BestGuessContext(context.Background(), ts...)
+}
+
+func (s *Session) bestGuess(ts ...Throw) (Guess, error) {
+	var dropped []Throw
+	if len(ts) > MAX_SUBSET_THROWS {
+		dropped = ts[:len(ts)-MAX_SUBSET_THROWS]
+		ts = ts[len(ts)-MAX_SUBSET_THROWS:]
+	}
+	g, err := s.bestSubset(ts)
+	if err != nil || len(dropped) == 0 {
+		return g, err
 	}
+	s.Options.Trace.Logf("dropped the %d oldest throws", len(dropped))
+	g.Dropped = dropped
+	g.Rejected = append(append([]Throw{}, dropped...), g.Rejected...)
+	return g, nil
+}
 
+// bestSubset is the guess of the largest set of throws that agree, or
+// ErrNoScore when no two of them do.
+func (s *Session) bestSubset(ts []Throw) (Guess, error) {
 	if len(ts) <= 1 {
-		s.Throws = ts
-		s.Scores, s.TotalScore = s.Layers().SumScores(s.Throws)
-		return s.MakeGuess()
+		return s.subsetGuess(ts)
 	}
 
-	g := Guess{Confidence: 0, Method: "reset"}
-	s.Throws = ts[len(ts)-2:]
-	if _, total := s.Layers().SumScores(ts[len(ts)-2:]); total == 0 {
-		if DEBUG {
-			log.Println("throws scored zero", ts[len(ts)-2:])
+	// look for the largest set of throws that agree on a stronghold, so a
+	// single misclick only costs that one throw
+	for size := len(ts); size >= 2; size-- {
+		g := Guess{}
+		found := false
+		tried := 0
+		for n, subset := range rPool(size, ts, nil, nil) {
+			if s.stopped() {
+				break
+			}
+			guess, err := s.subsetGuess(subset)
+			if err != nil && !errors.Is(err, ErrNoScore) {
+				if s.stopped() {
+					break
+				}
+				return Guess{}, err
+			}
+			tried++
+			if err != nil {
+				continue
+			}
+			if !found || guess.Confidence > g.Confidence {
+				g = guess
+				found = true
+				s.progress(g)
+			}
+			s.Options.Trace.Logf("combination %d of %d confidence %d", n, size, guess.Confidence)
 		}
-		return g
-	}
-	for n, ts := range rPool(2, ts, nil, nil) {
-		s.Throws = ts
-		s.Scores, s.TotalScore = s.Layers().SumScores(s.Throws)
-		if s.TotalScore == 0 {
+		if s.stopped() {
+			if !found {
+				return Guess{}, done(s.ctx)
+			}
+			// the session may still describe another combination
+			s.Options.Trace.Logf("out of time after %d combinations of %d throws", tried, size)
+			g.Partial = true
+			g.Rejected = rejectedThrows(ts, g.Used)
+			return g, nil
+		}
+		if !found {
+			s.Options.Trace.Logf("no consistent combination of %d throws", size)
 			continue
 		}
+		if tried > 1 {
+			// leave the session describing the chosen throws
+			if _, err := s.subsetGuess(g.Used); err != nil {
+				return Guess{}, err
+			}
+		}
+		g.Rejected = rejectedThrows(ts, g.Used)
+		return g, nil
+	}
+
+	return Guess{}, errorf(ErrNoScore, "no %d throws agree on a chunk", len(ts))
+}
+
+func (s *Session) subsetGuess(ts []Throw) (Guess, error) {
+	s.Options.Trace.subset()
+	if s.Options.Estimator == EstimatorPosterior {
+		return s.PosteriorGuess(ts...)
+	}
+	s.Throws = ts
+	scores, total, err := s.Layers().sumScores(s.context(), s.Throws)
+	if err != nil {
+		return Guess{}, err
+	}
+	s.Scores, s.TotalScore = scores, total
+	if s.TotalScore == 0 {
+		return Guess{}, errorf(ErrNoScore, "no chunk scored for %d throws", len(ts))
+	}
+	return s.MakeGuess()
+}
 
-		guess := s.MakeGuess()
-		if guess.Confidence > g.Confidence {
-			g = guess
+func rejectedThrows(all, used []Throw) []Throw {
+	rejected := []Throw{}
+	for _, t := range all {
+		keep := false
+		for _, u := range used {
+			if t == u {
+				keep = true
+			}
 		}
-		if DEBUG {
-			log.Println("combination", n, "confidence", guess.Confidence)
+		if !keep {
+			rejected = append(rejected, t)
 		}
 	}
-	return g
+	return rejected
 }
 
-func (s *Session) MakeGuess() Guess {
+func (s *Session) MakeGuess() (Guess, error) {
+	if len(s.Throws) == 0 {
+		return Guess{}, ErrNoThrows
+	}
 	if s.TotalScore == 0 {
-		panic("no score")
+		return Guess{}, ErrNoScore
 	}
 	if s.TotalScore < 0 {
-		panic("negative score")
+		return Guess{}, errorf(ErrNegativeScore, "total score %d", s.TotalScore)
 	}
 
-	t2 := time.Now() // clustering
+	tr := s.Options.Trace
+	start := time.Now() // clustering
 	chunks := s.Chunks()
 	averageScore := s.TotalScore / len(chunks)
 	pts := make([]dbscan.Clusterable, 0, len(chunks))
@@ -263,6 +496,8 @@ func (s *Session) MakeGuess() Guess {
 	// log.Println("observing", counted, "/", len(chunks), "above average", averageScore)
 	clustered := dbscan.Clusterize(pts, 1, s.LayerSet.ClusterWeight)
 	clusterGroups := make(map[int]clusters.Cluster)
+	clusterScores := make(map[int]int)
+	clusterOf := make(map[Chunk]int)
 	allowOutliers := true
 	maxScore := 0
 	for id, c := range clustered {
@@ -276,6 +511,7 @@ func (s *Session) MakeGuess() Guess {
 			scored := point.(ScoredChunk)
 			score := scored.Score
 			chunk := scored.Chunk
+			clusterOf[chunk] = id
 
 			x, y := chunk.Center()
 			group.Center[0] += float64(x * score)
@@ -284,7 +520,7 @@ func (s *Session) MakeGuess() Guess {
 			if score > maxScore {
 				maxScore = score
 			}
-			avgRing += RingID(chunk)
+			avgRing += s.profile().RingID(chunk)
 			kobs := clusters.Coordinates([]float64{float64(x), float64(y)})
 			group.Observations = append(group.Observations, kobs)
 		}
@@ -293,105 +529,103 @@ func (s *Session) MakeGuess() Guess {
 		avgRing /= len(c)
 
 		clusterGroups[id] = group
+		clusterScores[id] = totalScore
 		if len(c) > 1 {
 			allowOutliers = false
 		}
-		if DEBUG {
-			log.Println("cluster", id, "size", len(c), "ringavg", avgRing, "center", group.Center)
-		}
+		tr.Logf("cluster %d size %d ringavg %d center %.0f", id, len(c), avgRing, group.Center)
 	}
+	tr.Time("clustering", start)
 
-	t3 := time.Now() // choosing cluster
+	start = time.Now() // choosing cluster
 
+	t := s.Throws[len(s.Throws)-1]
 	display := make(clusters.Clusters, 0, len(clusterGroups))
-	for _, c := range clusterGroups {
+	displayIDs := make([]int, 0, len(clusterGroups))
+	candidates := make([]Candidate, 0, len(clusterGroups))
+	summaries := []ClusterTrace{}
+	for id, c := range clusterGroups {
 		if len(c.Observations) == 1 && !allowOutliers {
 			continue
 		}
 		display = append(display, c)
+		displayIDs = append(displayIDs, id)
+		candidates = append(candidates, NewCandidate(s.profile(), c.Center[0], c.Center[1], clusterScores[id], s.TotalScore, t))
+		summaries = append(summaries, ClusterTrace{
+			Center: [2]float64{c.Center[0], c.Center[1]},
+			Size:   len(c.Observations),
+			Score:  clusterScores[id],
+			Ring:   s.profile().RingID(ChunkFromPosition(c.Center[0], c.Center[1])),
+		})
 	}
-	if DEBUG {
-		log.Println("chunks", len(pts), "clusters", len(display))
-	}
+	tr.clusters(summaries)
+	tr.Logf("chunks %d clusters %d", len(pts), len(display))
 
 	if len(display) == 0 {
-		panic(fmt.Sprintf(`%t, %#v = %#v`, allowOutliers, display, clusterGroups))
+		return Guess{}, errorf(ErrNoClusters, "no cluster among %d chunks, outliers allowed %t", len(pts), allowOutliers)
 	}
 
 	// pick cluster closest to player
-	t := s.Throws[len(s.Throws)-1]
-	leastFar := display[0]
+	leastFar, leastID := display[0], displayIDs[0]
 	leastFound := dist(leastFar.Center[0], leastFar.Center[1], t.X, t.Y)
 	if len(display) > 1 {
-		for _, c := range display[1:] {
+		for n, c := range display[1:] {
 			dist := dist(c.Center[0], c.Center[1], t.X, t.Y)
 			if dist < leastFound {
 				leastFound = dist
-				leastFar = c
+				leastFar, leastID = c, displayIDs[n+1]
 			}
 		}
 	}
 
-	if DEBUG {
+	if tr.Logging() {
 		kmeans.SimplePlotter{}.Plot(display, 0)
-		log.Println("plotting closest cluster", leastFar.Center, leastFound)
 	}
+	tr.Logf("closest cluster %.0f, %.0f blocks away", leastFar.Center, leastFound)
 
-	t4 := time.Now() // choosing chunk
-	sx, sy := leastFar.Center[0], leastFar.Center[1]
-	highest := chunks[0]
-	highestScore := 0
-
-	closest := chunks[0]
-	closestDistance := closest.Dist(sx, sy) / float64(s.Scores[closest])
+	weights := make(map[Chunk]float64, len(chunks))
 	for _, c := range chunks {
-		dist := c.Dist(sx, sy) / float64(s.Scores[c])
-		if dist < closestDistance {
-			closest = c
-			closestDistance = dist
-		}
-		score := s.Scores[c]
-		if score > highestScore {
-			highestScore = score
-			highest = c
-		}
+		weights[c] = float64(s.Scores[c])
 	}
-
-	if DEBUG {
-		log.Println("total score", s.TotalScore)
-		l := 10
-		scored := s.ByScore()
-		if len(scored) < l {
-			l = len(scored)
-		}
-		for i, sc := range scored {
-			if sc == DEBUG_CHUNK {
-				l = i + 1
-				break
-			}
-		}
-		for _, chunk := range scored[:l] {
-			log.Println("chunk", chunk, "score", s.Scores[chunk])
-		}
-
-		log.Println("goal", DEBUG_CHUNK)
-		log.Println("highest score chunk", highest, "/", maxScore)
-		log.Printf(`clustering:%s rendering:%s choosing:%s`, t3.Sub(t2), t4.Sub(t3), time.Since(t4))
+	selector := s.selector()
+	chosen := selector.Select(chunks, weights, leastFar.Center[0], leastFar.Center[1])
+
+	// the chosen chunk may lie outside every listed cluster, and is then
+	// counted with the one the selector was aimed at
+	chosenID, ok := clusterOf[chosen]
+	if !ok || len(clusterGroups[chosenID].Observations) == 1 && !allowOutliers {
+		chosenID = leastID
 	}
-	if SELECTION_METHOD == "closest" {
-		return Guess{
-			Chunk:      closest,
-			Confidence: s.Scores[closest] * 1000 / (s.TotalScore + 2),
-			Method:     s.Layers().Code,
-			Used:       s.Throws,
-		}
+	for n, id := range displayIDs {
+		candidates[n].Chosen = id == chosenID
+	}
+	candidates = RankCandidates(candidates)
+
+	tr.Time("choosing", start)
+	if tr != nil {
+		s.traceChosen(chosen)
+		tr.Logf("%s chose %s scoring %d of %d, highest %d", selector.Name(), chosen, s.Scores[chosen], s.TotalScore, maxScore)
 	}
 	return Guess{
-		Chunk:      highest,
-		Confidence: s.Scores[highest] * 1000 / (s.TotalScore + 2),
+		Chunk:      chosen,
+		Confidence: s.Scores[chosen] * 1000 / (s.TotalScore + 2),
 		Method:     s.Layers().Code,
+		Estimator:  EstimatorLayers,
+		Selection:  selector.Name(),
 		Used:       s.Throws,
+		Candidates: candidates,
+	}, nil
+}
+
+// traceChosen records every layer's score for the chosen chunk.
+func (s *Session) traceChosen(chosen Chunk) {
+	tr := s.Options.Trace
+	tr.chunk(chosen, len(s.Throws))
+	for _, l := range s.Layers().Layers() {
+		tr.layer(chosen, l.Name, l.Layer(s.Throws, chosen))
 	}
+	tr.score(chosen, s.Scores[chosen])
+	tr.choose(chosen)
 }
 
 func rPool(p int, n []Throw, c []Throw, cc [][]Throw) [][]Throw {
@@ -1,17 +1,18 @@
 package throwlib
 
 import (
+	"context"
 	"log"
 	"math"
 	"math/rand"
+	"time"
 )
 
 const MAX_EYE_ANGLE = 0.85
 
-const SELECTION_EFFECT = true
+const YAW_SIGMA = 0.12
 
-var rings = [][2]int{{1408, 2688}, {4480, 5760}, {7552, 8832}, {10624, 11904}, {13696, 14976}, {16768, 18048}, {19840, 21120}, {22912, 24192}}
-var counts = []int{3, 6, 10, 15, 21, 28, 36, 9}
+const SELECTION_EFFECT = true
 
 func ChunkFromCenter(x, y int) Chunk {
 	return Chunk{(x - modLikePython(x, 16)) / 16, (y - modLikePython(y, 16)) / 16}
@@ -21,42 +22,44 @@ func ChunkFromPosition(x, y float64) Chunk {
 	return Chunk{(int(x) - modLikePython(int(x), 16)) / 16, (int(y) - modLikePython(int(y), 16)) / 16}
 }
 
+// Selectable scores the chunk with the default profile.
+//
+// Deprecated: use Profile.Selectable, which follows the requested version.
 func (c Chunk) Selectable(fromX, fromY float64) int {
-	ring := RingID(c)
+	return DefaultProfile.Selectable(c, fromX, fromY)
+}
+
+func (p *Profile) Selectable(c Chunk, fromX, fromY float64) int {
+	ring := p.RingID(c)
 	if ring == -1 {
 		return 0
 	}
 
-	count := counts[ring]
+	count := p.Counts[ring]
 	x, y := c.Center()
 	distPlayer := c.Dist(fromX, fromY)
 
 	atan := math.Atan2(-float64(x), float64(y))
 	inc := math.Pi * 2.0 / float64(count)
-	if c == DEBUG_CHUNK {
-		log.Println("-> selectable", c, "inc", inc)
-	}
 
+	snap := int(p.Displacement.Radius)
+	if snap < 2 {
+		snap = 2
+	}
 	score := 10
 	for a := atan - inc; a <= atan+inc; a += inc * 2 {
 		// a = neighboring spokes
 		dx, dy := -math.Sin(a), math.Cos(a)
-		for buffer := -120; buffer <= 120; buffer += 60 {
-			// buffer 120 blocks around max
-			d := float64(rings[ring][1] + buffer)
+		for buffer := -snap; buffer <= snap; buffer += snap / 2 {
+			// buffer around max, as far as a stronghold can be displaced
+			d := float64(p.Rings[ring][1] + buffer)
 			ox, oy := dx*d-fromX, dy*d-fromY
 			altDistPlayer := math.Sqrt(ox*ox + oy*oy)
 			// every time this stronghold would be closer, subtract a point
 			if distPlayer > altDistPlayer {
-				if c == DEBUG_CHUNK {
-					log.Println("-> distance", altDistPlayer, "<", distPlayer)
-				}
 				score--
 				// if at max buffer, discard this chunk entirely
-				if buffer == 120 {
-					if DEBUG {
-						log.Printf("deselected by %.0f,%.0f... %.0f < %.0f", ox, oy, altDistPlayer, distPlayer)
-					}
+				if buffer == snap {
 					return 0
 				}
 			}
@@ -65,14 +68,21 @@ func (c Chunk) Selectable(fromX, fromY float64) int {
 	return score
 }
 
+// RingID is the ring of the default profile the chunk is in.
+//
+// Deprecated: use Profile.RingID, which follows the requested version.
 func RingID(c Chunk) int {
+	return DefaultProfile.RingID(c)
+}
+
+func (p *Profile) RingID(c Chunk) int {
 	cDist := c.Dist(0, 0)
-	for n, ring := range rings {
+	for n, ring := range p.Rings {
 		minDist, maxDist := float64(ring[0]), float64(ring[1])
-		if cDist < minDist-110 {
+		if cDist < minDist-p.Displacement.Radius {
 			continue
 		}
-		if cDist > maxDist+110 {
+		if cDist > maxDist+p.Displacement.Radius {
 			continue
 		}
 		return n
@@ -83,15 +93,37 @@ func RingID(c Chunk) int {
 type Layer func([]Throw, Chunk) int
 
 type LayerSet struct {
-	Code string
+	Code string `json:"code"`
+
+	// in radians, unlike the degrees of Params.MaxEyeAngle
+	AnglePref       float64 `json:"angle_pref_rad"`
+	RingMod         float64 `json:"ring_mod"`
+	AverageDistance float64 `json:"average_distance"`
+	MathFactor      float64 `json:"math_factor"`
+	ClusterWeight   float64 `json:"cluster_weight"`
+
+	// how much each registered layer counts, by name, leaving out layers
+	// that should not run at all
+	Weights map[string]int `json:"weights"`
+
+	Profile *Profile `json:"-"`
+	Aim     *Aim     `json:"-"`
+	Params  *Params  `json:"-"`
+	Trace   *Trace   `json:"-"`
+}
 
-	AnglePref       float64
-	RingMod         float64
-	AverageDistance float64
-	MathFactor      float64
-	ClusterWeight   float64
+func (ls LayerSet) profile() *Profile {
+	if ls.Profile == nil {
+		return DefaultProfile
+	}
+	return ls.Profile
+}
 
-	Weights [3]int
+func (ls LayerSet) params() *Params {
+	if ls.Params == nil {
+		return ActiveParams()
+	}
+	return ls.Params
 }
 
 var ZeroEyeSet = LayerSet{
@@ -100,7 +132,7 @@ var ZeroEyeSet = LayerSet{
 	AnglePref:       radsFromDegs(0.08),
 	RingMod:         33,
 	AverageDistance: 0.22,
-	Weights:         [3]int{20, 100, 0},
+	Weights:         map[string]int{LayerAngle: 20, LayerRing: 100, LayerCrossAngle: 0},
 	ClusterWeight:   150,
 }
 
@@ -111,7 +143,7 @@ var OneEyeSet = LayerSet{
 	RingMod:         100,
 	AverageDistance: 0.61,
 	MathFactor:      440,
-	Weights:         [3]int{40, 100, 0},
+	Weights:         map[string]int{LayerAngle: 40, LayerRing: 100, LayerCrossAngle: 0},
 	ClusterWeight:   180,
 }
 
@@ -122,7 +154,7 @@ var TwoEyeSet = LayerSet{
 	RingMod:         150,
 	AverageDistance: 0.5,
 	MathFactor:      38,
-	Weights:         [3]int{20, 10, 100},
+	Weights:         map[string]int{LayerAngle: 20, LayerRing: 10, LayerCrossAngle: 100},
 	ClusterWeight:   180,
 }
 
@@ -133,60 +165,58 @@ var HyperSet = LayerSet{
 	RingMod:         0,
 	AverageDistance: 0.5,
 	MathFactor:      4,
-	Weights:         [3]int{100, 5, 100},
+	Weights:         map[string]int{LayerAngle: 100, LayerRing: 5, LayerCrossAngle: 100},
 	ClusterWeight:   150,
 }
 
-func (ls LayerSet) SumScores(throws []Throw) (map[Chunk]int, int) {
+func (ls LayerSet) SumScores(throws []Throw) (map[Chunk]int, int, error) {
+	return ls.sumScores(context.Background(), throws)
+}
+
+// sumScores gives up with the context's error once it is done.
+func (ls LayerSet) sumScores(ctx context.Context, throws []Throw) (map[Chunk]int, int, error) {
 	scores := make(map[Chunk]int)
 	reject := make(map[Chunk]bool)
 	count := make(map[Chunk]int)
 
+	defer ls.Trace.Time("scoring", time.Now())
 	layers := ls.Layers()
 	for _, t := range throws {
-		chunks := ChunksInThrow(t)
+		if err := done(ctx); err != nil {
+			return nil, 0, err
+		}
+		chunks, err := ls.profile().chunksInThrow(t, ls.Trace)
+		if err != nil {
+			return nil, 0, err
+		}
 		for _, c := range chunks {
 			count[c]++
 		}
 	}
+	scored := 0
 	for c := range count {
-		out := c == DEBUG_CHUNK
 		if reject[c] {
-			if out {
-				log.Println("-> sumscore: goal already rejected")
-			}
 			continue
 		}
-		score := 0
-		for n, l := range layers {
-			s := l(throws, c)
-			if s < 0 {
-				log.Println("sumscore: goal score", s, "for layer", n, "weight", ls.Weights[n])
-				panic("negative score")
+		scored++
+		if scored%SCORE_CHECK_EVERY == 0 {
+			if err := done(ctx); err != nil {
+				return nil, 0, err
 			}
-			if out {
-				log.Println("-> sumscore: goal score", s, "for layer", n)
-			}
-			if s == 0 {
-				score = 0
-				break
-			}
-			score += s * ls.Weights[n]
+		}
+		score, rejected, err := ls.scoreChunk(layers, throws, c)
+		if err != nil {
+			return nil, 0, err
 		}
 		if score == 0 {
 			reject[c] = true
 			if _, f := scores[c]; f {
 				delete(scores, c)
 			}
-			if out {
-				log.Println("sumscore: goal sum rejected")
-			}
+			ls.Trace.Reject(c, rejected)
 			continue
 		}
 		scores[c] += score
-		if out {
-			log.Println("sumscore: goal earned", score)
-		}
 	}
 	highest := 0
 	total := 0
@@ -197,10 +227,35 @@ func (ls LayerSet) SumScores(throws []Throw) (map[Chunk]int, int) {
 		}
 	}
 
-	if DEBUG {
-		log.Println("summed scores, total", len(count), "matched", len(scores), "rejected", len(reject), "highscore", highest)
+	ls.Trace.Logf("summed scores, total %d matched %d rejected %d highscore %d", len(count), len(scores), len(reject), highest)
+	return scores, total, nil
+}
+
+// scoreChunk weighs every layer's score for a chunk, or gives 0 and the name
+// of the layer that ruled it out.
+func (ls LayerSet) scoreChunk(layers []WeightedLayer, throws []Throw, c Chunk) (int, string, error) {
+	watched := ls.Trace.Watching(c)
+	if watched {
+		ls.Trace.chunk(c, len(throws))
+	}
+	score := 0
+	for _, l := range layers {
+		s := l.Layer(throws, c)
+		if s < 0 {
+			return 0, "", errorf(ErrNegativeScore, "layer %s scored %d at %s", l.Name, s, c)
+		}
+		if watched {
+			ls.Trace.layer(c, l.Name, s)
+		}
+		if s == 0 {
+			return 0, l.Name, nil
+		}
+		score += s * l.Weight
+	}
+	if watched {
+		ls.Trace.score(c, score)
 	}
-	return scores, total
+	return score, "", nil
 }
 
 func (ls LayerSet) Mutate() LayerSet {
@@ -221,27 +276,43 @@ func (ls LayerSet) Mutate() LayerSet {
 	return ls
 }
 
-func (ls LayerSet) Layers() []Layer {
-	return []Layer{ls.Angle, ls.Ring, ls.CrossAngle}
+// Layers builds every registered layer the set weighs, in registration order.
+func (ls LayerSet) Layers() []WeightedLayer {
+	layers := []WeightedLayer{}
+	for _, r := range registeredLayers() {
+		weight, ok := ls.Weights[r.name]
+		if !ok {
+			continue
+		}
+		layers = append(layers, WeightedLayer{Name: r.name, Weight: weight, Layer: r.build(ls)})
+	}
+	return layers
+}
+
+// WithLayer copies the set with a layer weighed in, or reweighed.
+func (ls LayerSet) WithLayer(name string, weight int) LayerSet {
+	weights := make(map[string]int, len(ls.Weights)+1)
+	for n, w := range ls.Weights {
+		weights[n] = w
+	}
+	weights[name] = weight
+	ls.Weights = weights
+	return ls
 }
 
 func (ls LayerSet) Ring(t []Throw, c Chunk) int {
-	ringID := RingID(c)
+	p := ls.profile()
+	ringID := p.RingID(c)
 	if ringID == -1 {
 		return 0
 	}
 
 	total := 1
 	for _, t := range t {
-		sel := c.Selectable(t.X, t.Y)
-		if c == DEBUG_CHUNK {
-			log.Println("-> ls.sel:", sel)
-		}
+		sel := p.NearestScore(c, t.X, t.Y)
 		if sel == 0 {
-			if c == DEBUG_CHUNK {
-				DEBUG = true
-				c.Selectable(t.X, t.Y)
-				panic("discarded debug chunk")
+			if ls.Trace.Watching(c) {
+				ls.Trace.Logf("%s is never the nearest stronghold from %.0f, %.0f", c, t.X, t.Y)
 			}
 			if SELECTION_EFFECT {
 				return 0
@@ -253,7 +324,7 @@ func (ls LayerSet) Ring(t []Throw, c Chunk) int {
 	}
 
 	cDist := c.Dist(0, 0)
-	minDist, maxDist := float64(rings[ringID][0]), float64(rings[ringID][1])
+	minDist, maxDist := float64(p.Rings[ringID][0]), float64(p.Rings[ringID][1])
 	preferred := minDist + (maxDist-minDist)*ls.AverageDistance
 	ring := cDist - preferred
 	if ring < ls.RingMod {
@@ -265,38 +336,40 @@ func (ls LayerSet) Ring(t []Throw, c Chunk) int {
 	if ring < ls.RingMod*3 {
 		total++
 	}
-	return total
+
+	// strongholds near the ring edges may have been displaced out of the
+	// ring, and past them only displaced ones are found
+	weight := p.Displacement.RingWeight(cDist, minDist, maxDist)
+	return int(math.Round(float64(total) * weight))
 }
 
 func (ls LayerSet) Angle(ts []Throw, c Chunk) int {
 	total := 1
 	for _, t := range ts {
-		delta := math.Abs(c.Angle(t.A, t.X, t.Y))
-		if delta > radsFromDegs(MAX_EYE_ANGLE) {
-			if c == DEBUG_CHUNK {
-				log.Println("-> ls.angle: discarded", delta)
+		delta := math.Abs(ls.profile().Angle(c, t.A, t.X, t.Y))
+		pref := t.Spread(ls.AnglePref * ls.Aim.Scale())
+		if delta > ls.params().MaxAngle(ls.Aim) {
+			if ls.Trace.Watching(c) {
+				ls.Trace.Logf("%s is %.2f° off a throw", c, degsFromRads(delta))
 			}
 			return 0
 		}
-		if delta < ls.AnglePref {
+		if delta < pref {
 			total++
 		}
-		if delta < ls.AnglePref*2 {
+		if delta < pref*2 {
 			total++
 		}
-		if delta < ls.AnglePref*4 {
+		if delta < pref*4 {
 			total++
 		}
-		if delta < ls.AnglePref*6 {
+		if delta < pref*6 {
 			total++
 		}
-		if delta < ls.AnglePref*9 {
+		if delta < pref*9 {
 			total++
 		}
 	}
-	if c == DEBUG_CHUNK {
-		log.Println("-> ls.angle:"+ls.Code+" goal scored", total)
-	}
 	return total / len(ts)
 }
 
@@ -306,13 +379,7 @@ func (ls LayerSet) CrossAngle(ts []Throw, c Chunk) int {
 	if len(ts) <= 1 {
 		return 1
 	}
-	printout := rand.Intn(10000) == 0
-	if c == DEBUG_CHUNK {
-		printout = true
-	}
-	if !DEBUG {
-		printout = false
-	}
+	printout := ls.Trace.Watching(c)
 	score := 1
 
 	tx, ty := 0.0, 0.0
@@ -346,7 +413,7 @@ func (ls LayerSet) CrossAngle(ts []Throw, c Chunk) int {
 				continue
 			}
 
-			log.Printf("crossangle: %s crossangle %.1f %.1f dist %.1f", c, nx, ny, distFromPerfect)
+			ls.Trace.Logf("crossangle: %s crossangle %.1f %.1f dist %.1f", c, nx, ny, distFromPerfect)
 		}
 	}
 	tx /= float64(count)
@@ -355,10 +422,10 @@ func (ls LayerSet) CrossAngle(ts []Throw, c Chunk) int {
 	distFromPerfect := c.Dist(tx, ty)
 
 	if printout {
-		log.Printf("crossangle: goal %s crossangle %.1f %.1f dist %.1f", c, tx, ty, distFromPerfect)
+		ls.Trace.Logf("crossangle: %s average crossangle %.1f %.1f dist %.1f", c, tx, ty, distFromPerfect)
 	}
 
-	if CROSSANGLE_EXPERIMENT {
+	if ls.params().CrossAngleExperiment {
 		if distFromPerfect < ls.MathFactor {
 			return 7
 		}
@@ -396,6 +463,10 @@ func radsFromDegs(degs float64) float64 {
 	return wrapRads(degs * (math.Pi / 180))
 }
 
+func degsFromRads(rads float64) float64 {
+	return rads * (180 / math.Pi)
+}
+
 func wrapRads(rads float64) float64 {
 	for rads < math.Pi {
 		rads += math.Pi * 2
@@ -406,7 +477,19 @@ func wrapRads(rads float64) float64 {
 	return rads
 }
 
-func ChunksInThrow(t Throw) ChunkList {
+// ChunksInThrow lists the chunks the throw may point at with the default
+// profile.
+//
+// Deprecated: use Profile.ChunksInThrow, which follows the requested version.
+func ChunksInThrow(t Throw) (ChunkList, error) {
+	return DefaultProfile.ChunksInThrow(t)
+}
+
+func (p *Profile) ChunksInThrow(t Throw) (ChunkList, error) {
+	return p.chunksInThrow(t, nil)
+}
+
+func (p *Profile) chunksInThrow(t Throw, tr *Trace) (ChunkList, error) {
 	angle := t.A
 	cx, cy := t.X, t.Y
 	dx, dy := -math.Sin(angle), math.Cos(angle)
@@ -415,7 +498,7 @@ func ChunksInThrow(t Throw) ChunkList {
 	chunks := make(ChunkList, 0)
 	chunksFound := map[Chunk]bool{}
 
-	pRing := t.RingID()
+	pRing := p.ThrowRing(t)
 
 	scanIters := 0
 	for {
@@ -432,18 +515,13 @@ func ChunksInThrow(t Throw) ChunkList {
 					continue
 				}
 				chunksFound[chunk] = true
-				ringID := RingID(chunk)
+				ringID := p.RingID(chunk)
 				if ringID == -1 {
-					if chunk == DEBUG_CHUNK {
-						log.Println("-> goal chunk out of ring")
-					}
+					tr.Reject(chunk, "outside rings")
 					continue
 				}
 				if ringID < pRing-1 || ringID > pRing+1 {
-					if chunk == DEBUG_CHUNK {
-						log.Println("-> goal chunk out of player ring", ringID, pRing)
-						log.Println(t)
-					}
+					tr.Reject(chunk, "far from player ring")
 					continue
 				}
 
@@ -484,7 +562,7 @@ func ChunksInThrow(t Throw) ChunkList {
 		// break
 
 		newDist := dist(0, 0, cx, cy)
-		if newDist > lastDist && newDist > float64(rings[len(rings)-1][1]+240) {
+		if newDist > lastDist && newDist > float64(p.Outer()+240) {
 			break
 		}
 		scanIters++
@@ -492,11 +570,11 @@ func ChunksInThrow(t Throw) ChunkList {
 			log.Println(blockX, blockY, nextX, nextY, distX, distY)
 		}
 		if scanIters > 10050 {
-			panic("overscanning")
+			return nil, errorf(ErrOverscan, "throw at %.0f, %.0f scanned past the rings", t.X, t.Y)
 		}
 	}
 	// log.Println("scan iterations:", scanIters)
-	return chunks
+	return chunks, nil
 }
 
 func modLikePython(d, m int) int {
@@ -42,12 +42,11 @@ func TestTriangulationAccuracy(t *testing.T) {
 	totals := []int{0, 0, 0, 0}
 	for n, test := range progressionTests {
 		t.Logf(`test %d`, n)
-		DEBUG_CHUNK = test.goal
 		sess := NewSession()
 		throws := test.throws
 
 		throw := NewBlindThrow(throws[0].X, throws[0].Y)
-		bestGuess := Chunk(sess.BestGuess(throw).Chunk)
+		bestGuess := Chunk(guessOf(sess, throw).Chunk)
 		chunkDist := int(bestGuess.ChunkDist(test.goal))
 
 		if chunkDist > 1000 {
@@ -58,7 +57,7 @@ func TestTriangulationAccuracy(t *testing.T) {
 			throws = throws[:3]
 		}
 		for num := range throws {
-			bestGuess := Chunk(sess.BestGuess(throws[:num+1]...).Chunk)
+			bestGuess := Chunk(guessOf(sess, throws[:num+1]...).Chunk)
 			chunkDist := int(bestGuess.ChunkDist(test.goal))
 
 			if chunkDist > 1000 {
@@ -84,8 +83,8 @@ func AverageBlindAccuracy(ls LayerSet, tests int) float64 {
 	total := int64(tests)
 	for i := int64(0); i < total; i++ {
 		throw := NewBlindThrow(rand.Float64()*400-200, rand.Float64()*400-200)
-		closest := ClosestStronghold(i, throw)
-		guess := Chunk(NewSession(ls).BestGuess(throw).Chunk)
+		closest := DefaultProfile.ClosestStronghold(i, throw)
+		guess := Chunk(guessOf(NewSession(ls), throw).Chunk)
 		sum += guess.ChunkDist(closest)
 	}
 	return sum / float64(total)
@@ -143,7 +142,6 @@ func AverageAccuracy(ls LayerSet, total int) (float64, int) {
 	distances := 0.0
 	totals := 0.0
 	for _, test := range progressionTests {
-		// DEBUG_CHUNK = test.goal
 		sess := NewSession(ls)
 		throws := test.throws
 		if len(throws) < total {
@@ -151,12 +149,12 @@ func AverageAccuracy(ls LayerSet, total int) (float64, int) {
 		}
 		var bestGuess Chunk
 		if total == 0 {
-			bestGuess = Chunk(sess.BestGuess(NewBlindThrow(throws[0].X, throws[0].Y)).Chunk)
+			bestGuess = Chunk(guessOf(sess, NewBlindThrow(throws[0].X, throws[0].Y)).Chunk)
 		} else {
 			if len(throws) > total {
 				throws = throws[:total]
 			}
-			bestGuess = Chunk(sess.BestGuess(throws...).Chunk)
+			bestGuess = Chunk(guessOf(sess, throws...).Chunk)
 		}
 		distances += bestGuess.ChunkDist(test.goal)
 		totals++
@@ -168,12 +166,11 @@ func AverageAccuracy(ls LayerSet, total int) (float64, int) {
 func TestEducatedAccuracy(t *testing.T) {
 	distance := 0
 	for _, test := range progressionTests {
-		DEBUG_CHUNK = test.goal
 		throw := test.throws[0]
 		goal := test.goal
 
 		sess := NewSession()
-		guess := Chunk(sess.BestGuess(throw).Chunk)
+		guess := Chunk(guessOf(sess, throw).Chunk)
 		chunkDist := int(guess.ChunkDist(goal))
 		distance += chunkDist / len(progressionTests)
 		t.Logf("goal %s, guess %s", goal, guess)
@@ -183,11 +180,10 @@ func TestEducatedAccuracy(t *testing.T) {
 
 func TestDeterministic(t *testing.T) {
 	for _, test := range progressionTests[:3] {
-		DEBUG_CHUNK = test.goal
 		throw := test.throws[0]
 
-		guess1 := NewSession().BestGuess(throw)
-		guess2 := NewSession().BestGuess(throw)
+		guess1 := guessOf(NewSession(), throw)
+		guess2 := guessOf(NewSession(), throw)
 
 		if guess1.Confidence != guess2.Confidence || guess1.Chunk != guess2.Chunk {
 			t.Errorf("mismatching guesses")
@@ -197,23 +193,86 @@ func TestDeterministic(t *testing.T) {
 
 func TestProgression(t *testing.T) {
 	test := progressionTests[14]
-	DEBUG_CHUNK = test.goal
-	DEBUG = true
 	sess := NewSession()
+	sess.Options.Trace = &Trace{Watch: []Chunk{test.goal}, Log: true}
 
 	// throw := NewBlindThrow(test.throws[0].X, test.throws[0].Y)
-	// guess := Chunk(sess.BestGuess(throw).Chunk)
+	// guess := Chunk(guessOf(sess, throw).Chunk)
 
 	// t.Logf("current angle: %f", guess.Angle(throw.A, throw.X, throw.Y))
 	// t.Logf("blind guess matched %d, guess: %s, goal: %s", len(sess.Scores), guess, test.goal)
 
 	for n, throw := range test.throws[:1] {
-		g := sess.BestGuess(test.throws[:n+1]...)
+		g := guessOf(sess, test.throws[:n+1]...)
 		guess := Chunk(g.Chunk)
 
 		t.Logf("current angle: %f", guess.Angle(throw.A, throw.X, throw.Y))
 		t.Logf("throw %d matched %d, config %v", n+1, len(sess.Scores), throw)
 		t.Logf("throw %d guess: `%s`, goal: %s", n+1, g, test.goal)
+		t.Logf("trace: %s", sess.Options.Trace.Summary())
+	}
+}
+
+func TestRejectOutlier(t *testing.T) {
+	test := progressionTests[0]
+	outlier := NewThrow(test.throws[1].X+200, test.throws[1].Y, 30)
+	throws := append([]Throw{outlier}, test.throws...)
+
+	guess, err := NewSession().BestGuess(throws...)
+	if err != nil {
+		t.Fatalf("failed instead of rejecting the outlier: %v", err)
+	}
+	if len(guess.Rejected) != 1 || guess.Rejected[0] != outlier {
+		t.Errorf("expected outlier to be rejected, got %v", guess.Rejected)
+	}
+	if len(guess.Used) != len(test.throws) {
+		t.Errorf("expected %d throws used, got %d", len(test.throws), len(guess.Used))
+	}
+}
+
+func TestCandidates(t *testing.T) {
+	for _, estimator := range []string{EstimatorLayers, EstimatorPosterior} {
+		for n, test := range progressionTests[:3] {
+			sess := NewSession()
+			sess.Options.Estimator = estimator
+			guess := guessOf(sess, test.throws[0])
+			if len(guess.Candidates) == 0 || len(guess.Candidates) > MAX_CANDIDATES {
+				t.Errorf("%s test %d has %d candidates", estimator, n, len(guess.Candidates))
+				continue
+			}
+			chosen := 0
+			for i, c := range guess.Candidates {
+				if c.Share <= 0 || c.Share > 1 {
+					t.Errorf("%s test %d candidate %d has share %f", estimator, n, i, c.Share)
+				}
+				if i > 0 && c.Share > guess.Candidates[i-1].Share {
+					t.Errorf("%s test %d candidates are not ranked", estimator, n)
+				}
+				if c.Chosen {
+					chosen++
+				}
+			}
+			if chosen != 1 {
+				t.Errorf("%s test %d has %d chosen candidates", estimator, n, chosen)
+			}
+		}
+	}
+}
+
+func TestNextThrow(t *testing.T) {
+	for n, test := range progressionTests[:3] {
+		sess := NewSession()
+		guessOf(sess, test.throws[0])
+		advice, ok := sess.NextThrow()
+		if !ok {
+			t.Errorf("test %d has no advice", n)
+			continue
+		}
+		moved := dist(float64(advice.Position[0]), float64(advice.Position[1]), test.throws[0].X, test.throws[0].Y)
+		if math.Abs(moved-float64(advice.Blocks)) > 2 {
+			t.Errorf("test %d advice moves %.0f blocks, not %d", n, moved, advice.Blocks)
+		}
+		t.Logf("test %d advice %#v", n, advice)
 	}
 }
 
@@ -421,3 +480,27 @@ Stronghold data from BadSap:
 /execute in minecraft:overworld run tp @s -3411.52 72.98 -3826.83 -121.51 -24.45
 /execute in minecraft:overworld run tp @s -3283.70 77.00 -3846.30 -120.16 -42.90
 /tp @s -1384 ~ -5080`
+
+func TestMergeThrows(t *testing.T) {
+	merged := MergeThrows(NewThrow(10, 20, 179), NewThrow(12, 20, -179))
+	if math.Abs(wrapRads(merged.A-math.Pi)) > 1e-9 {
+		t.Errorf("expected yaws to average across the wrap, got %.3f", degsFromRads(merged.A))
+	}
+	if merged.X != 11 || merged.Y != 20 || merged.Eyes != 2 {
+		t.Errorf("unexpected merged throw %#v", merged)
+	}
+
+	again := MergeThrows(merged, NewThrow(11, 20, 180))
+	if again.Eyes != 3 || math.Abs(again.Spread(YAW_SIGMA)-YAW_SIGMA/math.Sqrt(3)) > 1e-9 {
+		t.Errorf("expected three eyes to cut the spread by sqrt 3, got %#v", again)
+	}
+}
+
+// guessOf is the guess for throws known to score.
+func guessOf(sess *Session, ts ...Throw) Guess {
+	g, err := sess.BestGuess(ts...)
+	if err != nil {
+		panic(err)
+	}
+	return g
+}
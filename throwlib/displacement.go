@@ -0,0 +1,43 @@
+package throwlib
+
+import "math"
+
+// Displacement models the game moving a stronghold off its ring position to
+// a nearby valid biome. Stay is the chance it is not moved at all, otherwise
+// it lands anywhere within Radius blocks.
+type Displacement struct {
+	Radius float64
+	Stay   float64
+}
+
+var BiomeSnap = Displacement{Radius: SNAP_BLOCKS, Stay: 0.3}
+
+// shift is the chance a stronghold is moved at most u blocks further from
+// origin. A move uniform over a disc projects onto the radius as a semicircle.
+func (d Displacement) shift(u float64) float64 {
+	step := 0.0
+	if u >= 0 {
+		step = 1
+	}
+	if d.Radius <= 0 {
+		return step
+	}
+	r := d.Radius
+	var moved float64
+	switch {
+	case u <= -r:
+		moved = 0
+	case u >= r:
+		moved = 1
+	default:
+		moved = 0.5 + (u*math.Sqrt(r*r-u*u)+r*r*math.Asin(u/r))/(math.Pi*r*r)
+	}
+	return d.Stay*step + (1-d.Stay)*moved
+}
+
+// RingWeight is the chance that a stronghold placed uniformly between min and
+// max blocks from origin ends up at dist once displaced, relative to the
+// middle of the ring. It is 1 well inside the ring and fades across the edges.
+func (d Displacement) RingWeight(dist, min, max float64) float64 {
+	return d.shift(dist-min) - d.shift(dist-max)
+}
@@ -0,0 +1,39 @@
+package throwlib
+
+import (
+	"math"
+	"testing"
+)
+
+func TestRingWeight(t *testing.T) {
+	min, max := 1408.0, 2688.0
+	cases := []struct {
+		dist, want float64
+	}{
+		{2000, 1},
+		{min - BiomeSnap.Radius - 1, 0},
+		{max + BiomeSnap.Radius + 1, 0},
+	}
+	for _, c := range cases {
+		if got := BiomeSnap.RingWeight(c.dist, min, max); math.Abs(got-c.want) > 1e-9 {
+			t.Errorf("weight at %.0f is %f, not %f", c.dist, got, c.want)
+		}
+	}
+	inside := BiomeSnap.RingWeight(max-50, min, max)
+	outside := BiomeSnap.RingWeight(max+50, min, max)
+	if inside <= 0.5 || inside >= 1 || outside >= 0.5 || outside <= 0 {
+		t.Errorf("weights across the edge are %f and %f", inside, outside)
+	}
+}
+
+func TestRingLayerEdges(t *testing.T) {
+	ls := OneEyeSet
+	ls.RingMod = 0
+	from := []Throw{NewBlindThrow(0, 1500)}
+	middle := ls.Ring(from, ChunkFromPosition(0, 2000))
+	edge := ls.Ring(from, ChunkFromPosition(0, 2688+40))
+	past := ls.Ring(from, ChunkFromPosition(0, 2688+BiomeSnap.Radius+32))
+	if middle <= edge || edge <= past {
+		t.Errorf("expected the ring score to fade across the edge, got %d, %d and %d", middle, edge, past)
+	}
+}
@@ -0,0 +1,59 @@
+package throwlib
+
+import "fmt"
+
+const (
+	ErrCodeNoThrows      = "no_throws"
+	ErrCodeNoScore       = "no_score"
+	ErrCodeNegativeScore = "negative_score"
+	ErrCodeNoClusters    = "no_clusters"
+	ErrCodeOverscan      = "overscan"
+	ErrCodeStructureSet  = "bad_structure_set"
+	ErrCodeSelection     = "bad_selection"
+	ErrCodeTooManyClips  = "too_many_clips"
+	ErrCodeEstimator     = "bad_estimator"
+	ErrCodeLayer         = "bad_layer"
+	ErrCodeInternal      = "internal"
+)
+
+// Error is why a solve could not give a guess. Code is stable for clients to
+// switch on, and Message says what happened in this solve.
+type Error struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+// Is matches errors by code, so the values below match however detailed the
+// message of the error returned.
+func (e *Error) Is(target error) bool {
+	t, ok := target.(*Error)
+	return ok && t.Code == e.Code
+}
+
+var (
+	ErrNoThrows      = &Error{ErrCodeNoThrows, "no usable throws"}
+	ErrNoScore       = &Error{ErrCodeNoScore, "no chunk scored"}
+	ErrNegativeScore = &Error{ErrCodeNegativeScore, "negative score"}
+	ErrNoClusters    = &Error{ErrCodeNoClusters, "no cluster to choose from"}
+	ErrOverscan      = &Error{ErrCodeOverscan, "throw scanned past the rings"}
+	ErrStructureSet  = &Error{ErrCodeStructureSet, "structure set cannot place strongholds"}
+	ErrSelection     = &Error{ErrCodeSelection, "unknown selection strategy"}
+	ErrEstimator     = &Error{ErrCodeEstimator, "unknown estimator"}
+	ErrLayer         = &Error{ErrCodeLayer, "unknown layer"}
+)
+
+func errorf(base *Error, format string, args ...interface{}) *Error {
+	return &Error{base.Code, fmt.Sprintf(format, args...)}
+}
+
+// AsError gives the Error an error is, or wraps one from elsewhere.
+func AsError(err error) *Error {
+	if e, ok := err.(*Error); ok {
+		return e
+	}
+	return &Error{ErrCodeInternal, err.Error()}
+}
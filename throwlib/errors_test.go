@@ -0,0 +1,55 @@
+package throwlib
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestNoThrows(t *testing.T) {
+	if _, err := NewSession().BestGuess(); !errors.Is(err, ErrNoThrows) {
+		t.Errorf("expected no throws, got %v", err)
+	}
+
+	req := Request{Clips: []string{"not a clip", "nor this"}}
+	res, err := NewResponse(req)
+	if !errors.Is(err, ErrNoThrows) {
+		t.Fatalf("expected no throws, got %v", err)
+	}
+	if res.Method != "reset" || res.Error == nil || res.Error.Code != ErrCodeNoThrows {
+		t.Errorf("expected a reset response with the error, got %#v", res)
+	}
+	if len(res.Diagnostics) != 2 || res.Diagnostics[0].Reason != ReasonInvalid {
+		t.Errorf("expected the invalid clips to be diagnosed, got %#v", res.Diagnostics)
+	}
+}
+
+func TestNoScore(t *testing.T) {
+	sess := NewSession()
+	sess.Throws = progressionTests[0].throws[:1]
+	if _, err := sess.MakeGuess(); !errors.Is(err, ErrNoScore) {
+		t.Errorf("expected no score, got %v", err)
+	}
+}
+
+func TestNoScoreResponse(t *testing.T) {
+	req := Request{Clips: []string{
+		"/execute in minecraft:overworld run tp @s 0.00 70.00 30000.00 0.00 -30.00",
+	}}
+	res, err := NewResponse(req)
+	if !errors.Is(err, ErrNoScore) {
+		t.Fatalf("expected no score, got %v", err)
+	}
+	if res.Chunk != nil || res.Error == nil || res.Error.Code != ErrCodeNoScore {
+		t.Errorf("expected the error instead of a guess, got %#v", res)
+	}
+}
+
+func TestErrorCodes(t *testing.T) {
+	err := errorf(ErrOverscan, "throw at %d, %d scanned past the rings", 1, 2)
+	if !errors.Is(err, ErrOverscan) || errors.Is(err, ErrNoScore) {
+		t.Errorf("expected %v to match by code", err)
+	}
+	if AsError(errors.New("other")).Code != ErrCodeInternal {
+		t.Errorf("expected other errors to be internal")
+	}
+}
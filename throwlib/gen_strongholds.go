@@ -1,74 +1,137 @@
 package throwlib
 
-import (
-	"math"
-	"math/rand"
-)
+import "math"
 
+const EstimatorSeed = "seed"
+
+// ClosestStronghold is the default profile's closest stronghold of a seed.
+//
+// Deprecated: use Profile.ClosestStronghold, which follows the requested
+// version.
 func ClosestStronghold(seed int64, t Throw) Chunk {
-	DistanceChunks := 32
-	TotalCount := 20 // only need like 20
-	CountInRing := 3
+	return DefaultProfile.ClosestStronghold(seed, t)
+}
+
+// GenStrongholds places the default profile's strongholds of a seed.
+//
+// Deprecated: use Profile.GenStrongholds, which follows the requested version.
+func GenStrongholds(worldSeed int64) (positions []Chunk) {
+	return DefaultProfile.GenStrongholds(worldSeed)
+}
+
+// Strongholds is GenStrongholds as chunk coordinates.
+func (p *Profile) Strongholds(worldSeed int64) []Chunk {
+	positions := p.GenStrongholds(worldSeed)
+	chunks := make([]Chunk, len(positions))
+	for i, pos := range positions {
+		chunks[i] = ChunkFromCenter(pos[0], pos[1])
+	}
+	return chunks
+}
 
-	random := rand.New(rand.NewSource(seed))
-	angle := random.Float64() * math.Pi * 2
-	placedInRing := 0
-	currentRing := 0
+func (p *Profile) ClosestStronghold(seed int64, t Throw) Chunk {
+	return closestChunk(p.Strongholds(seed), t.X, t.Y)
+}
 
+func closestChunk(chunks []Chunk, x, y float64) Chunk {
 	var closest Chunk
-	closestDist := 10000000.0
-	for num := 0; num < TotalCount; num++ {
-		dist := float64(4*DistanceChunks + DistanceChunks*currentRing*6)
-		dist += (random.Float64() - 0.5) * float64(DistanceChunks) * 2.5
-		chunkX := int(math.Round(math.Cos(angle) * dist))
-		chunkY := int(math.Round(math.Sin(angle) * dist))
-		c := Chunk{chunkX, chunkY}
-		if c.Dist(t.X, t.Y) < closestDist {
-			closestDist = c.Dist(0, 0)
+	closestDist := math.Inf(1)
+	for _, c := range chunks {
+		if d := c.Dist(x, y); d < closestDist {
+			closestDist = d
 			closest = c
 		}
-		angle += math.Pi * 2.0 / float64(CountInRing)
-		placedInRing++
-		if placedInRing == CountInRing {
-			currentRing++
-			placedInRing = 0
-			CountInRing = CountInRing + 2*CountInRing/(currentRing+1)
-			if CountInRing > TotalCount-num {
-				CountInRing = TotalCount - num
-			}
-			angle += random.Float64() * math.Pi * 2.0
-		}
 	}
 	return closest
 }
 
-func GenStrongholds(worldSeed int64) (positions []Chunk) {
-	DistanceChunks := 32
-	TotalCount := 128
-	CountInRing := 3
+// seedAngle is how far off a throw points from anywhere a generated
+// stronghold could have snapped to.
+func (p *Profile) seedAngle(c Chunk, t Throw) float64 {
+	snap := math.Atan2(SNAP_BLOCKS, c.Dist(t.X, t.Y))
+	return math.Max(0, math.Abs(p.Angle(c, t.A, t.X, t.Y))-snap)
+}
 
-	random := rand.New(rand.NewSource(worldSeed))
-	angle := random.Float64() * math.Pi * 2
-	placedInRing := 0
-	currentRing := 0
+// SeedGuess finds the stronghold of a known seed that every aimed throw
+// agrees with. An eye always flies to the stronghold nearest the thrower, so
+// a stronghold only agrees when it is the nearest one and within the eye's
+// angle of error of where it could have snapped to. The seed only places it
+// within SNAP_BLOCKS, so every chunk there that the throws agree with is
+// taken as equally likely. Only forked profiles can be guessed from a seed.
+func (s *Session) SeedGuess(seed int64, ts []Throw) (Guess, bool) {
+	p := s.profile()
+	if !p.Forked {
+		s.Options.Trace.Logf("seeds cannot place strongholds for %s", p.Name)
+		return Guess{}, false
+	}
+	strongholds := p.Strongholds(seed)
+	maxAngle := s.params().MaxAngle(s.Options.Aim)
 
-	for num := 0; num < TotalCount; num++ {
-		dist := float64(4*DistanceChunks + DistanceChunks*currentRing*6)
-		dist += (random.Float64() - 0.5) * float64(DistanceChunks) * 2.5
-		chunkX := int(math.Round(math.Cos(angle)*dist))*16 + 8
-		chunkY := int(math.Round(math.Sin(angle)*dist))*16 + 8
-		positions = append(positions, Chunk{chunkX, chunkY})
-		angle += math.Pi * 2.0 / float64(CountInRing)
-		placedInRing++
-		if placedInRing == CountInRing {
-			currentRing++
-			placedInRing = 0
-			CountInRing = CountInRing + 2*CountInRing/(currentRing+1)
-			if CountInRing > TotalCount-num {
-				CountInRing = TotalCount - num
+	aimed := []Throw{}
+	for _, t := range ts {
+		if t.Type == Overworld {
+			aimed = append(aimed, t)
+		}
+	}
+	if len(aimed) == 0 {
+		return Guess{}, false
+	}
+
+	var target Chunk
+	for n, t := range aimed {
+		nearest := closestChunk(strongholds, t.X, t.Y)
+		if n > 0 && nearest != target {
+			return Guess{}, false
+		}
+		target = nearest
+		if p.seedAngle(target, t) > maxAngle {
+			return Guess{}, false
+		}
+	}
+
+	// every chunk the stronghold could have snapped to that all throws
+	// agree with, keeping the one they point at most closely
+	reach := SNAP_BLOCKS / 16
+	best, agreeing := target, 0
+	bestError := math.Inf(1)
+	for dx := -reach; dx <= reach; dx++ {
+		for dy := -reach; dy <= reach; dy++ {
+			c := Chunk{target[0] + dx, target[1] + dy}
+			if c.ChunkDist(target) > SNAP_BLOCKS {
+				continue
+			}
+			sum := 0.0
+			for _, t := range aimed {
+				off := math.Abs(p.Angle(c, t.A, t.X, t.Y))
+				if off > maxAngle {
+					sum = math.Inf(1)
+					break
+				}
+				sum += off * off
+			}
+			if math.IsInf(sum, 1) {
+				continue
+			}
+			agreeing++
+			if sum < bestError {
+				best, bestError = c, sum
 			}
-			angle += random.Float64() * math.Pi * 2.0
 		}
 	}
-	return positions
+	if agreeing == 0 {
+		return Guess{}, false
+	}
+
+	s.Throws = aimed
+	s.Scores, s.TotalScore = nil, 0
+	s.Posterior = nil
+	return Guess{
+		Chunk:       best,
+		Confidence:  1000 / agreeing,
+		Probability: 1 / float64(agreeing),
+		Method:      s.Layers().Code,
+		Estimator:   EstimatorSeed,
+		Used:        aimed,
+		Rejected:    rejectedThrows(ts, aimed),
+	}, true
 }
@@ -0,0 +1,55 @@
+package throwlib
+
+import (
+	"math"
+	"testing"
+)
+
+func TestClosestStrongholdCoversAllRings(t *testing.T) {
+	far := NewBlindThrow(20000, 15000)
+	closest := DefaultProfile.ClosestStronghold(7, far)
+	if DefaultProfile.RingID(closest) < 6 {
+		t.Errorf("closest stronghold to a far throw is %s", closest)
+	}
+}
+
+func TestJavaRandom(t *testing.T) {
+	if f := newJavaRandom(0).Float64(); f != 0.730967787376657 {
+		t.Errorf("new Random(0).nextDouble() is 0.730967787376657, got %v", f)
+	}
+	if l := newJavaRandom(0).Long(); l != -4962768465676381896 {
+		t.Errorf("new Random(0).nextLong() is -4962768465676381896, got %d", l)
+	}
+}
+
+func TestSeedGuess(t *testing.T) {
+	seed := int64(42)
+	player := NewBlindThrow(300, -200)
+	goal := JavaCurrent.ClosestStronghold(seed, player)
+	gx, gy := goal.Center()
+	aim := NewThrow(player.X, player.Y, degsFromRads(math.Atan2(player.X-float64(gx), float64(gy)-player.Y))+0.2)
+
+	sess := NewSession()
+	sess.Profile = &JavaCurrent
+	sess.Options.Seed = &seed
+	guess := guessOf(sess, aim)
+	if guess.Estimator != EstimatorSeed || Chunk(guess.Chunk).ChunkDist(goal) > SNAP_BLOCKS {
+		t.Errorf("expected a seed guess near %s, got %s with %s", goal, Chunk(guess.Chunk), guess.Estimator)
+	}
+	if guess.Probability <= 0 || guess.Probability >= 1 {
+		t.Errorf("one throw cannot place a snapped stronghold for certain, probability %f", guess.Probability)
+	}
+
+	wrong := NewThrow(player.X, player.Y, degsFromRads(aim.A)+90)
+	guess = guessOf(sess, wrong)
+	if guess.Estimator == EstimatorSeed {
+		t.Errorf("a throw away from every stronghold should fall back to heuristics")
+	}
+
+	sess = NewSession()
+	sess.Profile = &Java116
+	sess.Options.Seed = &seed
+	if guess := guessOf(sess, aim); guess.Estimator == EstimatorSeed {
+		t.Errorf("strongholds before 1.19 cannot be placed from a seed")
+	}
+}
@@ -0,0 +1,35 @@
+package throwlib
+
+import "math"
+
+// javaRandom is java.util.Random, which the game places strongholds with.
+type javaRandom struct {
+	seed int64
+}
+
+const (
+	javaMultiplier = 0x5DEECE66D
+	javaMask       = (1 << 48) - 1
+)
+
+func newJavaRandom(seed int64) *javaRandom {
+	return &javaRandom{(seed ^ javaMultiplier) & javaMask}
+}
+
+func (r *javaRandom) next(bits uint) int32 {
+	r.seed = (r.seed*javaMultiplier + 0xB) & javaMask
+	return int32(r.seed >> (48 - bits))
+}
+
+func (r *javaRandom) Long() int64 {
+	return int64(r.next(32))<<32 + int64(r.next(32))
+}
+
+func (r *javaRandom) Float64() float64 {
+	return float64(int64(r.next(26))<<27+int64(r.next(27))) * (1.0 / (1 << 53))
+}
+
+// javaRound is Math.round, which rounds halves up rather than away from zero.
+func javaRound(x float64) int {
+	return int(math.Floor(x + 0.5))
+}
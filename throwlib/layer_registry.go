@@ -0,0 +1,104 @@
+package throwlib
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+)
+
+const (
+	LayerAngle      = "angle"
+	LayerRing       = "ring"
+	LayerCrossAngle = "cross_angle"
+)
+
+// LayerFactory builds a layer for the set it is scored in, so it can use the
+// set's tuning and profile.
+type LayerFactory func(ls LayerSet) Layer
+
+// WeightedLayer is a built layer with its name and weight in a set.
+type WeightedLayer struct {
+	Name   string
+	Weight int
+	Layer  Layer
+}
+
+type registeredLayer struct {
+	name  string
+	build LayerFactory
+}
+
+var layerLock sync.RWMutex
+
+var layerRegistry = []registeredLayer{
+	{LayerAngle, func(ls LayerSet) Layer { return ls.Angle }},
+	{LayerRing, func(ls LayerSet) Layer { return ls.Ring }},
+	{LayerCrossAngle, func(ls LayerSet) Layer { return ls.CrossAngle }},
+}
+
+// RegisterLayer adds a named layer that sets can weigh in with WithLayer.
+// Registering a name again replaces its layer but keeps its place in order.
+func RegisterLayer(name string, build LayerFactory) error {
+	if name == "" || build == nil {
+		return fmt.Errorf("layer needs a name and a factory")
+	}
+	layerLock.Lock()
+	defer layerLock.Unlock()
+	for n, r := range layerRegistry {
+		if r.name == name {
+			layerRegistry[n].build = build
+			return nil
+		}
+	}
+	layerRegistry = append(layerRegistry, registeredLayer{name, build})
+	return nil
+}
+
+// UnregisterLayer removes a layer added with RegisterLayer. The built in
+// layers stay, as every set is scored with them.
+func UnregisterLayer(name string) error {
+	switch name {
+	case LayerAngle, LayerRing, LayerCrossAngle:
+		return fmt.Errorf("built in layer %q cannot be removed", name)
+	}
+	layerLock.Lock()
+	defer layerLock.Unlock()
+	for n, r := range layerRegistry {
+		if r.name == name {
+			layerRegistry = append(layerRegistry[:n:n], layerRegistry[n+1:]...)
+			return nil
+		}
+	}
+	return fmt.Errorf("no layer %q is registered", name)
+}
+
+// LayerNames lists the registered layers in the order they are scored.
+func LayerNames() []string {
+	names := []string{}
+	for _, r := range registeredLayers() {
+		names = append(names, r.name)
+	}
+	return names
+}
+
+// CheckLayers fails with ErrLayer for the first name that is not registered,
+// as the layer would otherwise be left out of scoring without a word.
+func CheckLayers(weights map[string]int) error {
+	names := LayerNames()
+	for name := range weights {
+		found := false
+		for _, n := range names {
+			found = found || n == name
+		}
+		if !found {
+			return errorf(ErrLayer, "no layer %q is registered, expected one of %s", name, strings.Join(names, ", "))
+		}
+	}
+	return nil
+}
+
+func registeredLayers() []registeredLayer {
+	layerLock.RLock()
+	defer layerLock.RUnlock()
+	return append([]registeredLayer{}, layerRegistry...)
+}
@@ -0,0 +1,91 @@
+package throwlib
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestDefaultLayers(t *testing.T) {
+	names := LayerNames()
+	if len(names) < 3 || names[0] != LayerAngle || names[1] != LayerRing || names[2] != LayerCrossAngle {
+		t.Errorf("expected the built in layers first, got %v", names)
+	}
+	for _, ls := range []LayerSet{ZeroEyeSet, OneEyeSet, TwoEyeSet, HyperSet} {
+		if got := len(ls.Layers()); got != 3 {
+			t.Errorf("%s runs %d layers", ls.Code, got)
+		}
+	}
+	if err := RegisterLayer("", nil); err == nil {
+		t.Errorf("expected a layer without a name to be refused")
+	}
+	if err := UnregisterLayer(LayerRing); err == nil {
+		t.Errorf("expected a built in layer to stay")
+	}
+}
+
+func TestCustomLayer(t *testing.T) {
+	test := progressionTests[0]
+	first := guessOf(NewSession(), test.throws[0])
+
+	// pretend the player already searched around the first guess
+	checked := Chunk(first.Chunk)
+	err := RegisterLayer("checked", func(ls LayerSet) Layer {
+		return func(ts []Throw, c Chunk) int {
+			if c.ChunkDist(checked) < 160 {
+				return 0
+			}
+			return 1
+		}
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		if err := UnregisterLayer("checked"); err != nil {
+			t.Error(err)
+		}
+	})
+
+	weighed := OneEyeSet.WithLayer("checked", 1)
+	if _, ok := OneEyeSet.Weights["checked"]; ok {
+		t.Errorf("WithLayer changed the original set")
+	}
+	if len(weighed.Layers()) != 4 {
+		t.Errorf("expected the custom layer to run, got %d layers", len(weighed.Layers()))
+	}
+
+	sess := NewSession()
+	sess.Options.Layers = map[string]int{"checked": 1}
+	g := guessOf(sess, test.throws[0])
+	if Chunk(g.Chunk).ChunkDist(checked) < 160 {
+		t.Errorf("guessed %s inside the checked area", Chunk(g.Chunk))
+	}
+}
+
+func TestUnregisterLayer(t *testing.T) {
+	before := len(LayerNames())
+	if err := RegisterLayer("removed", func(ls LayerSet) Layer { return ls.Angle }); err != nil {
+		t.Fatal(err)
+	}
+	if err := UnregisterLayer("removed"); err != nil {
+		t.Fatal(err)
+	}
+	if after := len(LayerNames()); after != before {
+		t.Errorf("expected %d layers once removed, got %d", before, after)
+	}
+	if err := UnregisterLayer("removed"); err == nil {
+		t.Errorf("expected removing it twice to fail")
+	}
+}
+
+func TestRequestLayers(t *testing.T) {
+	req := Request{Clips: []string{"/execute in minecraft:overworld run tp @s 294.96 116.93 -486.85 -499.05 -25.35"}}
+	req.Options.Layers = map[string]int{LayerRing: 2}
+	if _, err := NewResponse(req); err != nil {
+		t.Errorf("expected a registered layer to be weighed, got %v", err)
+	}
+	req.Options.Layers = map[string]int{"rnig": 2}
+	if res, err := NewResponse(req); !errors.Is(err, ErrLayer) || res.Error == nil {
+		t.Errorf("expected an unknown layer to fail, got %v", err)
+	}
+}
@@ -0,0 +1,169 @@
+package throwlib
+
+//go:generate go run ../priorgen -o nearest_table.go
+
+import (
+	"encoding/base64"
+	"fmt"
+	"math"
+	"math/rand"
+	"sync"
+)
+
+const (
+	PRIOR_PLAYER_BUCKET = 256
+	PRIOR_DIST_BUCKET   = 128
+	PRIOR_DIST_BUCKETS  = 32
+	PRIOR_DIRECTIONS    = 4
+)
+
+// NearestTable is how likely each chunk is to hold the stronghold nearest a
+// player, learned by simulating worlds. It is indexed by the player's
+// distance from origin, the direction from the player relative to origin,
+// and the distance from the player, each value being a density per chunk
+// scaled so the likeliest of its row is 255.
+type NearestTable struct {
+	Players int
+	Data    []uint8
+}
+
+func (nt *NearestTable) index(player, direction, distance int) int {
+	return (player*PRIOR_DIRECTIONS+direction)*PRIOR_DIST_BUCKETS + distance
+}
+
+func priorBuckets(c Chunk, fromX, fromY float64) (int, int, int) {
+	x, y := c.Center()
+	rp := math.Sqrt(fromX*fromX + fromY*fromY)
+	dx, dy := float64(x)-fromX, float64(y)-fromY
+	d := math.Sqrt(dx*dx + dy*dy)
+
+	direction := 0
+	if rp > 0 && d > 0 {
+		// 0 is straight away from origin, the last is straight towards it
+		cos := (dx*fromX + dy*fromY) / (d * rp)
+		phi := math.Acos(math.Max(-1, math.Min(1, cos)))
+		direction = int(phi / math.Pi * PRIOR_DIRECTIONS)
+		if direction == PRIOR_DIRECTIONS {
+			direction--
+		}
+	}
+	distance := int(d / PRIOR_DIST_BUCKET)
+	if distance >= PRIOR_DIST_BUCKETS {
+		distance = PRIOR_DIST_BUCKETS - 1
+	}
+	return int(rp / PRIOR_PLAYER_BUCKET), direction, distance
+}
+
+func (nt *NearestTable) Lookup(c Chunk, fromX, fromY float64) uint8 {
+	player, direction, distance := priorBuckets(c, fromX, fromY)
+	if player >= nt.Players {
+		player = nt.Players - 1
+	}
+	return nt.Data[nt.index(player, direction, distance)]
+}
+
+// BuildNearestTable simulates seeds, dropping players around every ring of
+// the profile and recording where the stronghold nearest to them is.
+func BuildNearestTable(p *Profile, seeds int, samples int, random *rand.Rand) *NearestTable {
+	players := (p.Outer()+int(p.Displacement.Radius))/PRIOR_PLAYER_BUCKET + 1
+	counts := make([]float64, players*PRIOR_DIRECTIONS*PRIOR_DIST_BUCKETS)
+	nt := &NearestTable{Players: players}
+
+	for seed := 0; seed < seeds; seed++ {
+		strongholds := p.Strongholds(random.Int63())
+		for player := 0; player < players; player++ {
+			for n := 0; n < samples; n++ {
+				rp := (float64(player) + random.Float64()) * PRIOR_PLAYER_BUCKET
+				angle := random.Float64() * math.Pi * 2
+				fx, fy := -math.Sin(angle)*rp, math.Cos(angle)*rp
+				closest := closestChunk(strongholds, fx, fy)
+				_, direction, distance := priorBuckets(closest, fx, fy)
+				counts[nt.index(player, direction, distance)]++
+			}
+		}
+	}
+
+	nt.Data = make([]uint8, len(counts))
+	for player := 0; player < players; player++ {
+		for direction := 0; direction < PRIOR_DIRECTIONS; direction++ {
+			// spread each count over the chunks at that distance
+			row := make([]float64, PRIOR_DIST_BUCKETS)
+			highest := 0.0
+			for distance := range row {
+				ring := (float64(distance) + 0.5) * PRIOR_DIST_BUCKET
+				row[distance] = counts[nt.index(player, direction, distance)] / ring
+				highest = math.Max(highest, row[distance])
+			}
+			if highest == 0 {
+				continue
+			}
+			for distance, v := range row {
+				scaled := math.Ceil(v / highest * 255)
+				nt.Data[nt.index(player, direction, distance)] = uint8(scaled)
+			}
+		}
+	}
+	return nt
+}
+
+// GoSource writes the table as a Go file holding a base64 string.
+func (nt *NearestTable) GoSource(pkg, name string) []byte {
+	enc := nt.encode()
+	return []byte(fmt.Sprintf("// Code generated by priorgen. DO NOT EDIT.\n\npackage %s\n\nconst %sPlayers = %d\n\nvar %s string = `%s`\n", pkg, name, nt.Players, name, enc))
+}
+
+func (nt *NearestTable) encode() string {
+	return base64.StdEncoding.EncodeToString(nt.Data)
+}
+
+func decodeNearestTable(players int, data string) *NearestTable {
+	b, err := base64.StdEncoding.DecodeString(data)
+	if err != nil || len(b) != players*PRIOR_DIRECTIONS*PRIOR_DIST_BUCKETS {
+		return nil
+	}
+	return &NearestTable{Players: players, Data: b}
+}
+
+var loadNearest sync.Once
+var java116Nearest *NearestTable
+
+// NearestDensity is how likely c is to hold the stronghold nearest to the
+// player, relative to the likeliest chunk at that spot. Profiles without a
+// simulated table fall back to checking the neighbouring spokes.
+func (p *Profile) NearestDensity(c Chunk, fromX, fromY float64) float64 {
+	nt := p.NearestTable()
+	if nt == nil {
+		return float64(p.Selectable(c, fromX, fromY)) / 10
+	}
+	if p.RingID(c) == -1 {
+		return 0
+	}
+	return float64(nt.Lookup(c, fromX, fromY)) / 255
+}
+
+// NearestScore is NearestDensity as a layer score, from 0 when c cannot be
+// the nearest stronghold up to 10, losing a point each time it halves.
+func (p *Profile) NearestScore(c Chunk, fromX, fromY float64) int {
+	if p.NearestTable() == nil {
+		return p.Selectable(c, fromX, fromY)
+	}
+	d := p.NearestDensity(c, fromX, fromY)
+	if d == 0 {
+		return 0
+	}
+	score := 10 + int(math.Round(math.Log2(d)))
+	if score < 1 {
+		score = 1
+	}
+	return score
+}
+
+func (p *Profile) NearestTable() *NearestTable {
+	if !p.Simulated {
+		return nil
+	}
+	loadNearest.Do(func() {
+		java116Nearest = decodeNearestTable(nearestTablePlayers, nearestTable)
+	})
+	return java116Nearest
+}
@@ -0,0 +1,69 @@
+package throwlib
+
+import (
+	"math/rand"
+	"testing"
+)
+
+// The embedded table must be regenerated with make prior whenever placement
+// changes, or the prior scores strongholds where they are no longer placed.
+func TestNearestTableUpToDate(t *testing.T) {
+	// as priorgen builds it by default
+	nt := BuildNearestTable(&Java116, 4000, 4, rand.New(rand.NewSource(1)))
+	if nt.Players != nearestTablePlayers || nt.encode() != nearestTable {
+		t.Errorf("nearest_table.go does not match placement, run make prior")
+	}
+}
+
+func TestNearestTable(t *testing.T) {
+	nt := Java116.NearestTable()
+	if nt == nil {
+		t.Fatal("embedded table did not decode")
+	}
+	if nt.Players*PRIOR_PLAYER_BUCKET <= Java116.Outer() {
+		t.Errorf("table covers %d player buckets, not past the outer ring", nt.Players)
+	}
+
+	// a player in the first ring is most likely closest to a stronghold in it
+	near := ChunkFromPosition(-120, 1900)
+	far := ChunkFromPosition(-120, 5000)
+	if Java116.NearestScore(near, 0, 1700) <= Java116.NearestScore(far, 0, 1700) {
+		t.Errorf("%s should score above %s", near, far)
+	}
+	if score := Java116.NearestScore(ChunkFromPosition(0, 3500), 0, 1700); score != 0 {
+		t.Errorf("chunk between rings scored %d", score)
+	}
+
+	custom := NewConcentricProfile(&Java116, "custom", 20, 3, 128)
+	if custom.NearestTable() != nil {
+		t.Error("custom placement should not use the simulated table")
+	}
+	if custom.NearestScore(near, 0, 1700) != custom.Selectable(near, 0, 1700) {
+		t.Error("custom placement should fall back to Selectable")
+	}
+}
+
+func TestBuildNearestTable(t *testing.T) {
+	nt := BuildNearestTable(&Java116, 20, 1, rand.New(rand.NewSource(1)))
+	if len(nt.Data) != nt.Players*PRIOR_DIRECTIONS*PRIOR_DIST_BUCKETS {
+		t.Fatalf("table has %d values for %d players", len(nt.Data), nt.Players)
+	}
+	for player := 0; player < nt.Players; player++ {
+		for direction := 0; direction < PRIOR_DIRECTIONS; direction++ {
+			highest := uint8(0)
+			for distance := 0; distance < PRIOR_DIST_BUCKETS; distance++ {
+				if v := nt.Data[nt.index(player, direction, distance)]; v > highest {
+					highest = v
+				}
+			}
+			if highest != 0 && highest != 255 {
+				t.Errorf("row %d,%d peaks at %d", player, direction, highest)
+			}
+		}
+	}
+
+	got := decodeNearestTable(nt.Players, nt.encode())
+	if got == nil || string(got.Data) != string(nt.Data) {
+		t.Error("table did not survive encoding")
+	}
+}
@@ -0,0 +1,220 @@
+package throwlib
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"math"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Params is a versioned bundle of everything tuned by hand or by the tuning
+// tests, so a retuned set can ship without a new build. Keys of angles name
+// their unit, as MaxEyeAngle is in degrees but a set's AnglePref in radians.
+type Params struct {
+	Version string `json:"version"`
+
+	Sets []LayerSet `json:"sets"`
+
+	MaxEyeAngle          float64 `json:"max_eye_angle_deg"`
+	SelectionMethod      string  `json:"selection_method"`
+	CrossAngleExperiment bool    `json:"crossangle_experiment"`
+}
+
+var DefaultParams = Params{
+	Version: "builtin",
+
+	Sets: []LayerSet{ZeroEyeSet, OneEyeSet, TwoEyeSet, HyperSet},
+
+	MaxEyeAngle:          MAX_EYE_ANGLE,
+	SelectionMethod:      SELECTION_METHOD,
+	CrossAngleExperiment: CROSSANGLE_EXPERIMENT,
+}
+
+var paramsLock sync.RWMutex
+var activeParams = &DefaultParams
+
+// ActiveParams is the bundle new sessions solve with.
+func ActiveParams() *Params {
+	paramsLock.RLock()
+	defer paramsLock.RUnlock()
+	return activeParams
+}
+
+// UseParams swaps the bundle for new sessions. Sessions already running keep
+// the one they started with.
+func UseParams(p *Params) {
+	paramsLock.Lock()
+	defer paramsLock.Unlock()
+	activeParams = p
+}
+
+// Set is the layer set with the given code. Bundles are checked for every
+// set when loaded, so a bundle built by hand that lacks one uses the builtin.
+func (p *Params) Set(code string) LayerSet {
+	for _, ls := range p.Sets {
+		if ls.Code == code {
+			return ls
+		}
+	}
+	for _, ls := range DefaultParams.Sets {
+		if ls.Code == code {
+			return ls
+		}
+	}
+	log.Printf("no layer set %q in params %s, using %s", code, p.Version, TwoEyeSet.Code)
+	return TwoEyeSet
+}
+
+// MaxAngle is the furthest in radians an eye can point from its stronghold
+// before the chunk is ruled out. It only ever loosens with the aim, since the
+// limit also covers where in the chunk the eye flies to.
+func (p *Params) MaxAngle(aim *Aim) float64 {
+	return radsFromDegs(p.MaxEyeAngle) * math.Max(aim.Scale(), 1)
+}
+
+func (p *Params) validate() error {
+	if p.Version == "" {
+		return fmt.Errorf("params have no version")
+	}
+	if p.MaxEyeAngle <= 0 {
+		return fmt.Errorf("params %s have max eye angle %.2f degrees", p.Version, p.MaxEyeAngle)
+	}
+	if _, err := SelectorByName(p.SelectionMethod); err != nil {
+		return fmt.Errorf("params %s: %s", p.Version, err.Error())
+	}
+	for _, want := range DefaultParams.Sets {
+		found := false
+		for _, ls := range p.Sets {
+			if ls.Code == want.Code && len(ls.Weights) > 0 {
+				found = true
+			}
+		}
+		if !found {
+			return fmt.Errorf("params %s have no %s layer set", p.Version, want.Code)
+		}
+	}
+	for _, ls := range p.Sets {
+		if err := ls.validate(); err != nil {
+			return fmt.Errorf("params %s: %s", p.Version, err.Error())
+		}
+	}
+	return nil
+}
+
+// validate checks the set's tuning is in range, so a bundle missing a field
+// is refused rather than scoring with a zero.
+func (ls LayerSet) validate() error {
+	fields := []struct {
+		name     string
+		value    float64
+		min, max float64
+		open     bool
+	}{
+		{"angle_pref_rad", ls.AnglePref, 0, math.Pi, true},
+		{"cluster_weight", ls.ClusterWeight, 0, math.Inf(1), true},
+		{"average_distance", ls.AverageDistance, 0, 1, false},
+		{"ring_mod", ls.RingMod, 0, math.Inf(1), false},
+		{"math_factor", ls.MathFactor, 0, math.Inf(1), false},
+	}
+	for _, f := range fields {
+		low := f.value < f.min || (f.open && f.value == f.min)
+		if math.IsNaN(f.value) || math.IsInf(f.value, 0) || low || f.value > f.max {
+			return fmt.Errorf("%s set has %s %v", ls.Code, f.name, f.value)
+		}
+	}
+	total := 0
+	for name, w := range ls.Weights {
+		if w < 0 {
+			return fmt.Errorf("%s set weighs %s at %d", ls.Code, name, w)
+		}
+		total += w
+	}
+	if total == 0 {
+		return fmt.Errorf("%s set weighs every layer at 0", ls.Code)
+	}
+	return nil
+}
+
+func ParseParams(b []byte) (*Params, error) {
+	p := &Params{}
+	if err := json.Unmarshal(b, p); err != nil {
+		return nil, err
+	}
+	if err := p.validate(); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// LoadParams reads a bundle from a file, or from a URL when source is one.
+func LoadParams(source string) (*Params, error) {
+	if !strings.HasPrefix(source, "http://") && !strings.HasPrefix(source, "https://") {
+		b, err := ioutil.ReadFile(source)
+		if err != nil {
+			return nil, err
+		}
+		return ParseParams(b)
+	}
+
+	cli := http.Client{Timeout: 5 * time.Second}
+	res, err := cli.Get(source)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != 200 {
+		return nil, fmt.Errorf("fetching params, status code %d", res.StatusCode)
+	}
+	b, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+	return ParseParams(b)
+}
+
+func (p *Params) Save(path string) error {
+	b, err := json.MarshalIndent(p, "", "\t")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path, b, 0644)
+}
+
+// ParamsWatcher keeps the active bundle in step with a file or URL.
+type ParamsWatcher struct {
+	Source string
+	Every  time.Duration
+
+	lock   sync.Mutex
+	loaded time.Time
+}
+
+// Reload loads the source and makes it active, keeping the current bundle
+// when the source cannot be loaded.
+func (w *ParamsWatcher) Reload() (*Params, error) {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	w.loaded = time.Now()
+	p, err := LoadParams(w.Source)
+	if err != nil {
+		return nil, err
+	}
+	UseParams(p)
+	return p, nil
+}
+
+// Refresh reloads once the last load is older than Every.
+func (w *ParamsWatcher) Refresh() (*Params, error) {
+	w.lock.Lock()
+	stale := time.Since(w.loaded) >= w.Every
+	w.lock.Unlock()
+	if !stale {
+		return ActiveParams(), nil
+	}
+	return w.Reload()
+}
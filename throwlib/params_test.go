@@ -0,0 +1,100 @@
+package throwlib
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestDefaultParams(t *testing.T) {
+	if err := DefaultParams.validate(); err != nil {
+		t.Fatal(err)
+	}
+	b, _ := json.Marshal(DefaultParams)
+	p, err := ParseParams(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p.Set(TwoEyeSet.Code).Weights[LayerCrossAngle] != TwoEyeSet.Weights[LayerCrossAngle] {
+		t.Errorf("weights did not survive a round trip")
+	}
+
+	broken := DefaultParams
+	broken.Sets = broken.Sets[:2]
+	b, _ = json.Marshal(broken)
+	if _, err := ParseParams(b); err == nil {
+		t.Errorf("expected params missing layer sets to be refused")
+	}
+
+	for field, value := range map[string]interface{}{"angle_pref_rad": 0, "cluster_weight": nil, "average_distance": 2} {
+		sets := []map[string]interface{}{}
+		b, _ = json.Marshal(DefaultParams.Sets)
+		json.Unmarshal(b, &sets)
+		if value == nil {
+			delete(sets[0], field)
+		} else {
+			sets[0][field] = value
+		}
+		bundle := map[string]interface{}{}
+		b, _ = json.Marshal(DefaultParams)
+		json.Unmarshal(b, &bundle)
+		bundle["sets"] = sets
+		b, _ = json.Marshal(bundle)
+		if _, err := ParseParams(b); err == nil {
+			t.Errorf("expected params with a bad %s to be refused", field)
+		}
+	}
+}
+
+func TestLoadParams(t *testing.T) {
+	retuned := DefaultParams
+	retuned.Version = "retuned-2"
+	retuned.MaxEyeAngle = 1.2
+
+	dir, err := ioutil.TempDir("", "throwpro")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "params.json")
+	if err := retuned.Save(path); err != nil {
+		t.Fatal(err)
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.ServeFile(w, r, path)
+	}))
+	defer server.Close()
+
+	for _, source := range []string{path, server.URL} {
+		p, err := LoadParams(source)
+		if err != nil {
+			t.Fatalf("loading %s: %s", source, err)
+		}
+		if p.Version != retuned.Version || p.MaxEyeAngle != retuned.MaxEyeAngle {
+			t.Errorf("loaded %#v from %s", p, source)
+		}
+	}
+
+	defer UseParams(&DefaultParams)
+	w := &ParamsWatcher{Source: path}
+	if _, err := w.Reload(); err != nil {
+		t.Fatal(err)
+	}
+	res, err := NewResponse(Request{Clips: []string{"/execute in minecraft:overworld run tp @s 294.96 116.93 -486.85 -499.05 -25.35"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Params != retuned.Version {
+		t.Errorf("expected the response to come from %s, got %s", retuned.Version, res.Params)
+	}
+
+	w.Source = filepath.Join(dir, "missing.json")
+	if _, err := w.Reload(); err == nil || ActiveParams().Version != retuned.Version {
+		t.Errorf("a failed reload should keep the current params")
+	}
+}
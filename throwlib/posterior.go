@@ -0,0 +1,242 @@
+package throwlib
+
+import (
+	"math"
+	"sort"
+	"time"
+)
+
+const (
+	EstimatorLayers    = "layers"
+	EstimatorPosterior = "posterior"
+)
+
+// how far in blocks a chunk can be from a posterior candidate's most likely
+// chunk and still count towards it
+const CANDIDATE_SPACING = 512
+
+// PosteriorModel treats each throw's yaw error as a normal distribution and
+// keeps a normalized probability for every candidate chunk. The prior is the
+// ring density of Prior times NearestDensity, how likely the chunk is to hold
+// the stronghold nearest the player, so that the probabilities match how often
+// the stronghold is found in simulated worlds.
+type PosteriorModel struct {
+	YawSigma   float64
+	BlindSigma float64
+
+	Profile *Profile
+	Aim     *Aim
+	Params  *Params
+	Trace   *Trace
+}
+
+func (pm PosteriorModel) params() *Params {
+	if pm.Params == nil {
+		return ActiveParams()
+	}
+	return pm.Params
+}
+
+func (pm PosteriorModel) profile() *Profile {
+	if pm.Profile == nil {
+		return DefaultProfile
+	}
+	return pm.Profile
+}
+
+var DefaultPosterior = PosteriorModel{
+	YawSigma:   radsFromDegs(YAW_SIGMA),
+	BlindSigma: radsFromDegs(30),
+}
+
+// Prior is the relative density of a stronghold in the chunk, before any
+// throw is considered. Strongholds are spread evenly by angle and distance
+// within a ring, so the density per chunk falls off with distance from origin,
+// and blurred across the ring edges by biome displacement.
+func (pm PosteriorModel) Prior(c Chunk) float64 {
+	p := pm.profile()
+	ring := p.RingID(c)
+	if ring == -1 {
+		return 0
+	}
+	minDist, maxDist := float64(p.Rings[ring][0]), float64(p.Rings[ring][1])
+	cDist := math.Max(c.Dist(0, 0), 1)
+
+	density := float64(p.Counts[ring]) / (2 * math.Pi * cDist * (maxDist - minDist))
+	return density * p.Displacement.RingWeight(cDist, minDist, maxDist)
+}
+
+// LogLikelihood of a throw pointing where it did, were the stronghold in c.
+func (pm PosteriorModel) LogLikelihood(t Throw, c Chunk) float64 {
+	delta := pm.profile().Angle(c, t.A, t.X, t.Y)
+	sigma := t.Spread(pm.YawSigma * pm.Aim.Scale())
+	if t.Type == Blind {
+		sigma = pm.BlindSigma
+	} else if math.Abs(delta) > pm.params().MaxAngle(pm.Aim) {
+		return math.Inf(-1)
+	}
+	return -0.5 * (delta / sigma) * (delta / sigma)
+}
+
+// Posterior returns the probability of each candidate chunk holding the
+// stronghold the throws point at. The probabilities sum to 1, or the map is
+// empty when no chunk is consistent with every throw.
+func (pm PosteriorModel) Posterior(throws []Throw) (map[Chunk]float64, error) {
+	if len(throws) == 0 {
+		return nil, ErrNoThrows
+	}
+	defer pm.Trace.Time("posterior", time.Now())
+	logs := make(map[Chunk]float64)
+	for _, t := range throws {
+		chunks, err := pm.profile().chunksInThrow(t, pm.Trace)
+		if err != nil {
+			return nil, err
+		}
+		for _, c := range chunks {
+			logs[c] = 0
+		}
+	}
+
+	last := throws[len(throws)-1]
+	highest := math.Inf(-1)
+	for c := range logs {
+		prior := pm.Prior(c) * pm.profile().NearestDensity(c, last.X, last.Y)
+		if prior <= 0 {
+			delete(logs, c)
+			pm.Trace.Reject(c, "prior")
+			continue
+		}
+		l := math.Log(prior)
+		for _, t := range throws {
+			l += pm.LogLikelihood(t, c)
+		}
+		if math.IsInf(l, -1) {
+			delete(logs, c)
+			pm.Trace.Reject(c, "angle")
+			continue
+		}
+		logs[c] = l
+		if l > highest {
+			highest = l
+		}
+	}
+
+	total := 0.0
+	for c, l := range logs {
+		logs[c] = math.Exp(l - highest)
+		total += logs[c]
+	}
+	for c := range logs {
+		logs[c] /= total
+	}
+
+	pm.Trace.Logf("posterior over %d chunks", len(logs))
+	return logs, nil
+}
+
+func (s *Session) ByProbability() []Chunk {
+	chunks := make([]Chunk, 0, len(s.Posterior))
+	for c := range s.Posterior {
+		chunks = append(chunks, c)
+	}
+	sort.Slice(chunks, func(i, j int) bool {
+		pi, pj := s.Posterior[chunks[i]], s.Posterior[chunks[j]]
+		if pi == pj {
+			if chunks[i][0] == chunks[j][0] {
+				return chunks[i][1] < chunks[j][1]
+			}
+			return chunks[i][0] < chunks[j][0]
+		}
+		return pi > pj
+	})
+	return chunks
+}
+
+func (s *Session) PosteriorGuess(ts ...Throw) (Guess, error) {
+	s.Throws = ts
+	pm := DefaultPosterior
+	pm.Profile = s.profile()
+	pm.Aim = s.Options.Aim
+	pm.Params = s.params()
+	pm.Trace = s.Options.Trace
+	post, err := pm.Posterior(ts)
+	if err != nil {
+		return Guess{}, err
+	}
+	s.Posterior = post
+	if len(s.Posterior) == 0 {
+		return Guess{}, errorf(ErrNoScore, "no chunk agrees with %d throws", len(ts))
+	}
+
+	// selectors aim at the posterior mean, as they aim at the closest
+	// cluster's center with layer scores
+	chunks := s.ByProbability()
+	cx, cy := 0.0, 0.0
+	for c, p := range s.Posterior {
+		x, y := c.Center()
+		cx += float64(x) * p
+		cy += float64(y) * p
+	}
+	sel := s.selector()
+	best, selection := sel.Select(chunks, s.Posterior, cx, cy), sel.Name()
+	p := s.Posterior[best]
+	if tr := s.Options.Trace; tr != nil {
+		tr.chunk(best, len(ts))
+		tr.score(best, int(p*1000))
+		tr.choose(best)
+		tr.Logf("%s chose %s with probability %.3f", selection, best, p)
+	}
+	return Guess{
+		Chunk:       best,
+		Confidence:  int(p * 1000),
+		Probability: p,
+		Method:      s.Layers().Code,
+		Estimator:   EstimatorPosterior,
+		Selection:   selection,
+		Used:        s.Throws,
+		Candidates:  s.posteriorCandidates(chunks, best),
+	}, nil
+}
+
+// posteriorCandidates groups the most likely chunks into candidates, each
+// sharing the probability of the chunks within CANDIDATE_SPACING of it.
+func (s *Session) posteriorCandidates(byProbability []Chunk, best Chunk) []Candidate {
+	t := s.Throws[len(s.Throws)-1]
+	heads := []Chunk{}
+	shares := []float64{}
+	chosen := -1
+	for _, c := range byProbability {
+		group := -1
+		for n, h := range heads {
+			if c.ChunkDist(h) <= CANDIDATE_SPACING {
+				group = n
+				break
+			}
+		}
+		if group == -1 && len(heads) < MAX_CANDIDATES {
+			group = len(heads)
+			heads = append(heads, c)
+			shares = append(shares, 0)
+		}
+		if group == -1 {
+			continue
+		}
+		shares[group] += s.Posterior[c]
+		if c == best {
+			chosen = group
+		}
+	}
+
+	candidates := make([]Candidate, 0, len(heads))
+	for n, h := range heads {
+		x, y := s.profile().Staircase(h)
+		candidates = append(candidates, Candidate{
+			Chunk:    h,
+			Coords:   [2]int{x, y},
+			Share:    shares[n],
+			Distance: h.Dist(t.X, t.Y),
+			Chosen:   n == chosen,
+		})
+	}
+	return RankCandidates(candidates)
+}
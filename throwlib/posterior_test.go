@@ -0,0 +1,120 @@
+package throwlib
+
+import (
+	"errors"
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestPosteriorNormalized(t *testing.T) {
+	for n, test := range progressionTests[:3] {
+		for num := range test.throws {
+			post, err := DefaultPosterior.Posterior(test.throws[:num+1])
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(post) == 0 {
+				t.Errorf("test %d with %d throws has an empty posterior", n, num+1)
+				continue
+			}
+			total := 0.0
+			for _, p := range post {
+				total += p
+			}
+			if math.Abs(total-1) > 1e-9 {
+				t.Errorf("test %d with %d throws sums to %f", n, num+1, total)
+			}
+		}
+	}
+}
+
+func TestRequestEstimator(t *testing.T) {
+	req := Request{Clips: []string{"/execute in minecraft:overworld run tp @s 294.96 116.93 -486.85 -499.05 -25.35"}}
+	req.Options.Estimator = EstimatorPosterior
+	if res, err := NewResponse(req); err != nil || res.Estimator != EstimatorPosterior {
+		t.Errorf("asked for the posterior, estimated by %q (%v)", res.Estimator, err)
+	}
+	req.Options.Estimator = "bayes"
+	if res, err := NewResponse(req); !errors.Is(err, ErrEstimator) || res.Error == nil {
+		t.Errorf("expected an unknown estimator to fail, got %v", err)
+	}
+}
+
+// simulatedThrow is a throw from a random spot within the first rings towards
+// the nearest stronghold of a random world, with the usual yaw error.
+func simulatedThrow(random *rand.Rand) (Throw, Chunk) {
+	strongholds := DefaultProfile.Strongholds(random.Int63())
+	r, angle := random.Float64()*3000, random.Float64()*2*math.Pi
+	x, y := -math.Sin(angle)*r, math.Cos(angle)*r
+	goal := closestChunk(strongholds, x, y)
+	tx, ty := DefaultProfile.TargetOf(goal)
+	throw := throwAt(x, y, float64(tx), float64(ty))
+	throw.A += radsFromDegs(YAW_SIGMA) * random.NormFloat64()
+	return throw, goal
+}
+
+func TestPosteriorAccuracy(t *testing.T) {
+	averages := map[string]float64{}
+	for _, estimator := range []string{EstimatorLayers, EstimatorPosterior} {
+		random := rand.New(rand.NewSource(3))
+		distance, total := 0.0, 0
+		for n := 0; n < 150; n++ {
+			throw, goal := simulatedThrow(random)
+			sess := NewSession()
+			sess.Options.Estimator = estimator
+			guess, err := sess.BestGuess(throw)
+			if errors.Is(err, ErrNoScore) {
+				continue
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			distance += goal.ChunkDist(guess.Chunk)
+			total++
+		}
+		averages[estimator] = distance / float64(total)
+		t.Logf("average %s accuracy for %d simulated throws: %.0f blocks", estimator, total, averages[estimator])
+	}
+	// within 5%, as clustering the layer scores varies with map order
+	if post, layers := averages[EstimatorPosterior], averages[EstimatorLayers]; post > layers*1.05 {
+		t.Errorf("posterior averages %.0f blocks, worse than the layers' %.0f", post, layers)
+	}
+}
+
+// The probability given to the chunks around the likeliest one should be how
+// often the stronghold is among them.
+func TestPosteriorCalibrated(t *testing.T) {
+	random := rand.New(rand.NewSource(3))
+	predicted, found, total := 0.0, 0.0, 0
+	for n := 0; n < 300; n++ {
+		throw, goal := simulatedThrow(random)
+		post, err := DefaultPosterior.Posterior([]Throw{throw})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(post) == 0 {
+			continue
+		}
+		best := Chunk{}
+		for c, p := range post {
+			if p > post[best] {
+				best = c
+			}
+		}
+		for c, p := range post {
+			if c.ChunkDist(best) <= 160 {
+				predicted += p
+			}
+		}
+		if goal.ChunkDist(best) <= 160 {
+			found++
+		}
+		total++
+	}
+	predicted, found = predicted/float64(total), found/float64(total)
+	t.Logf("predicted %.3f of strongholds near the likeliest chunk, found %.3f", predicted, found)
+	if math.Abs(predicted-found) > 0.05 {
+		t.Errorf("posterior predicted %.3f near the likeliest chunk, but found %.3f", predicted, found)
+	}
+}
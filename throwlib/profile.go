@@ -0,0 +1,183 @@
+package throwlib
+
+import (
+	"math"
+	"sort"
+)
+
+// Profile describes stronghold placement and eye targeting for one version
+// of the game. Target and Stairs are block offsets inside the chunk, the
+// first being where the eye flies to and the second where to dig down.
+type Profile struct {
+	Name string
+
+	Rings  [][2]int
+	Counts []int
+
+	Target [2]int
+	Stairs [2]int
+
+	Displacement Displacement
+
+	// generation parameters, in chunks
+	Distance    int
+	Spread      int
+	Total       int
+	Legacy      bool
+	RingSpacing int
+	// each stronghold's biome search draws from its own forked random, so
+	// where strongholds are placed before snapping follows from the seed alone
+	Forked bool
+
+	// whether the embedded nearest stronghold table was simulated for this placement
+	Simulated bool
+}
+
+var Java18 = Profile{
+	Name: "1.8",
+
+	Rings:  [][2]int{{640, 1152}},
+	Counts: []int{3},
+
+	Target: [2]int{8, 8},
+	Stairs: [2]int{4, 4},
+
+	Displacement: BiomeSnap,
+
+	Distance: 32,
+	Spread:   3,
+	Total:    3,
+	Legacy:   true,
+}
+
+var Java116 = Profile{
+	Name: "1.16",
+
+	Rings:  [][2]int{{1408, 2688}, {4480, 5760}, {7552, 8832}, {10624, 11904}, {13696, 14976}, {16768, 18048}, {19840, 21120}, {22912, 24192}},
+	Counts: []int{3, 6, 10, 15, 21, 28, 36, 9},
+
+	Target: [2]int{8, 8},
+	Stairs: [2]int{4, 4},
+
+	Displacement: BiomeSnap,
+
+	Distance:    32,
+	Spread:      3,
+	Total:       128,
+	RingSpacing: 6,
+	Simulated:   true,
+}
+
+var JavaCurrent = func() Profile {
+	p := Java116
+	p.Name = "current"
+	p.Stairs = [2]int{8, 8}
+	p.Forked = true
+	return p
+}()
+
+var Profiles = map[string]*Profile{
+	Java18.Name:      &Java18,
+	Java116.Name:     &Java116,
+	JavaCurrent.Name: &JavaCurrent,
+	"1.19":           &JavaCurrent,
+}
+
+var DefaultProfile = &Java116
+
+// ProfileFor looks up a version, falling back to the default profile.
+func ProfileFor(version string) *Profile {
+	if p, ok := Profiles[version]; ok {
+		return p
+	}
+	return DefaultProfile
+}
+
+func (p *Profile) TargetOf(c Chunk) (int, int) {
+	return c[0]*16 + p.Target[0], c[1]*16 + p.Target[1]
+}
+
+func (p *Profile) Staircase(c Chunk) (int, int) {
+	return c[0]*16 + p.Stairs[0], c[1]*16 + p.Stairs[1]
+}
+
+// Angle is like Chunk.Angle, but measured to where the eye flies in this version.
+func (p *Profile) Angle(c Chunk, a, sx, sy float64) float64 {
+	x, y := p.TargetOf(c)
+	atan := math.Atan2(sx-float64(x), float64(y)-sy) + math.Pi*2
+	atan = math.Mod(atan, math.Pi*2)
+	return wrapRads(a - atan)
+}
+
+func (p *Profile) ThrowRing(t Throw) int {
+	dist := int(dist(t.X, t.Y, 0, 0))
+	for id, r := range p.Rings {
+		if dist < r[1] {
+			return id
+		}
+	}
+	return len(p.Rings)
+}
+
+func (p *Profile) Outer() int {
+	return p.Rings[len(p.Rings)-1][1]
+}
+
+// GenStrongholds places every stronghold of a seed, as block positions at the
+// center of each stronghold chunk, before biome snapping moves them. It draws
+// from the same random sequence as the game, but only forked profiles keep to
+// it past the first stronghold: older versions also draw from it while
+// searching for biomes, which cannot be followed without the world's biomes.
+func (p *Profile) GenStrongholds(worldSeed int64) (positions []Chunk) {
+	random := newJavaRandom(worldSeed)
+	angle := random.Float64() * math.Pi * 2
+
+	if p.Legacy {
+		for num := 0; num < p.Total; num++ {
+			dist := (1.25 + random.Float64()) * float64(p.Distance)
+			chunkX := javaRound(math.Cos(angle)*dist)*16 + 8
+			chunkY := javaRound(math.Sin(angle)*dist)*16 + 8
+			positions = append(positions, Chunk{chunkX, chunkY})
+			angle += math.Pi * 2.0 / float64(p.Total)
+		}
+		return positions
+	}
+
+	CountInRing := p.Spread
+	placedInRing := 0
+	currentRing := 0
+	for num := 0; num < p.Total; num++ {
+		dist := float64(4*p.Distance + p.Distance*currentRing*p.RingSpacing)
+		dist += (random.Float64() - 0.5) * float64(p.Distance) * 2.5
+		chunkX := javaRound(math.Cos(angle)*dist)*16 + 8
+		chunkY := javaRound(math.Sin(angle)*dist)*16 + 8
+		positions = append(positions, Chunk{chunkX, chunkY})
+		if p.Forked {
+			random.Long()
+		}
+		angle += math.Pi * 2.0 / float64(CountInRing)
+		placedInRing++
+		if placedInRing == CountInRing {
+			currentRing++
+			placedInRing = 0
+			CountInRing = CountInRing + 2*CountInRing/(currentRing+1)
+			if CountInRing > p.Total-num {
+				CountInRing = p.Total - num
+			}
+			angle += random.Float64() * math.Pi * 2.0
+		}
+	}
+	return positions
+}
+
+// ProfileNames are the canonical version names, leaving out aliases.
+func ProfileNames() []string {
+	names := make([]string, 0, len(Profiles))
+	for name, p := range Profiles {
+		if p.Name == name {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,45 @@
+package throwlib
+
+import "testing"
+
+func TestProfileFor(t *testing.T) {
+	if ProfileFor("1.8") != &Java18 {
+		t.Errorf("1.8 did not map to the legacy profile")
+	}
+	if ProfileFor("") != DefaultProfile || ProfileFor("beta") != DefaultProfile {
+		t.Errorf("unknown versions should use the default profile")
+	}
+	if ProfileFor("1.19") != &JavaCurrent {
+		t.Errorf("1.19 did not map to the current profile")
+	}
+	for _, name := range ProfileNames() {
+		if name == "1.19" {
+			t.Errorf("profile names should leave out the 1.19 alias, got %v", ProfileNames())
+		}
+	}
+}
+
+func TestProfileStrongholdsInRings(t *testing.T) {
+	for _, p := range []*Profile{&Java18, &Java116} {
+		for seed := int64(0); seed < 20; seed++ {
+			positions := p.GenStrongholds(seed)
+			if len(positions) != p.Total {
+				t.Fatalf("%s seed %d placed %d strongholds", p.Name, seed, len(positions))
+			}
+			perRing := make([]int, len(p.Rings))
+			for _, pos := range positions {
+				ring := p.RingID(ChunkFromCenter(pos[0], pos[1]))
+				if ring == -1 {
+					t.Errorf("%s seed %d stronghold %v is outside every ring", p.Name, seed, pos)
+					continue
+				}
+				perRing[ring]++
+			}
+			for ring, count := range perRing {
+				if count != p.Counts[ring] {
+					t.Errorf("%s seed %d ring %d has %d strongholds, not %d", p.Name, seed, ring, count, p.Counts[ring])
+				}
+			}
+		}
+	}
+}
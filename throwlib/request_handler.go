@@ -1,7 +1,9 @@
 package throwlib
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
 	"strconv"
@@ -11,7 +13,36 @@ import (
 type Request struct {
 	Clips   []string `json:"clips"`
 	Options struct {
-		Hyper bool `json:"hyper"`
+		Hyper     bool   `json:"hyper"`
+		Estimator string `json:"estimator"`
+		Version   string `json:"version"`
+		// strongholds are placed from the seed on 1.19 and later only, and
+		// within the reach of biome snapping rather than exactly
+		Seed      *int64 `json:"seed,omitempty"`
+		Confirmed *Chunk `json:"confirmed,omitempty"`
+		RingAbove bool   `json:"ring_above,omitempty"`
+
+		// fov in degrees as the game shows it, or between -1 and 1 as
+		// options.txt stores it where 0 is 70 degrees, sensitivity as in
+		// options.txt and pixel error in screen pixels
+		FOV         float64  `json:"fov,omitempty"`
+		Sensitivity *float64 `json:"sensitivity,omitempty"`
+		PixelError  float64  `json:"pixel_error,omitempty"`
+
+		Calibration *Calibration `json:"calibration,omitempty"`
+
+		// weights for registered layers, on top of the chosen layer set,
+		// refusing names that are not registered
+		Layers map[string]int `json:"layers,omitempty"`
+
+		// closest, highest or travel, defaulting to the params' method
+		Selection string `json:"selection,omitempty"`
+
+		// trace the solve, recording scores for the watched chunks too
+		Trace bool    `json:"trace,omitempty"`
+		Watch []Chunk `json:"watch,omitempty"`
+
+		StructureSet json.RawMessage `json:"structure_set,omitempty"`
 	} `json:"options"`
 	Session string `json:"session_id"`
 }
@@ -22,33 +53,176 @@ type Response struct {
 	Player *[2]int `json:"player"`
 	Portal *[2]int `json:"portal"`
 
-	Method     string   `json:"method"`
-	Confidence int      `json:"confidence"`
-	Keep       []string `json:"keep"`
+	Method      string   `json:"method"`
+	Version     string   `json:"version,omitempty"`
+	Params      string   `json:"params,omitempty"`
+	Estimator   string   `json:"estimator,omitempty"`
+	Selection   string   `json:"selection,omitempty"`
+	Confidence  int      `json:"confidence"`
+	Probability float64  `json:"probability,omitempty"`
+	Keep        []string `json:"keep"`
+
+	// residuals are in degrees, one per throw used
+	Uncertainty *Ellipse  `json:"uncertainty,omitempty"`
+	Residuals   []float64 `json:"residuals,omitempty"`
+
+	Diagnostics []Diagnostic `json:"diagnostics"`
+	Candidates  []Candidate  `json:"candidates,omitempty"`
+	Advice      *Advice      `json:"advice,omitempty"`
+
+	Predictions []RingPrediction `json:"predictions,omitempty"`
+	Correction  *Correction      `json:"correction,omitempty"`
+
+	// hypotheses are only listed when the throws disagree on a stronghold
+	Crossed    bool         `json:"crossed,omitempty"`
+	Hypotheses []Hypothesis `json:"hypotheses,omitempty"`
+
+	// the solve ran out of time, so the guess is the best found by then
+	Partial bool `json:"partial,omitempty"`
+
+	// set with a reset method when no guess could be made
+	Error *Error `json:"error,omitempty"`
+	Trace *Trace `json:"trace,omitempty"`
+}
+
+const (
+	ReasonInvalid   = "invalid"
+	ReasonPortal    = "portal"
+	ReasonSimilar   = "similar"
+	ReasonRejected  = "rejected"
+	ReasonElsewhere = "elsewhere"
+	ReasonDropped   = "dropped"
+)
+
+// Diagnostic describes what happened to one clip of the request. Residual is
+// the yaw error in degrees against the chosen chunk, as read before any
+// calibration, Uncertainty the yaw error in degrees expected of the throw it
+// went into once similar clips are averaged, and Reason is set when the clip
+// did not contribute to the guess.
+type Diagnostic struct {
+	Clip        string   `json:"clip"`
+	Throw       *Throw   `json:"throw,omitempty"`
+	Type        string   `json:"type,omitempty"`
+	Similar     bool     `json:"similar"`
+	Residual    *float64 `json:"residual,omitempty"`
+	Uncertainty float64  `json:"uncertainty,omitempty"`
+	Reason      string   `json:"reason,omitempty"`
+}
+
+// aim fills in what the request left out of the player's setup from the
+// default, or is nil when it says nothing about it.
+func (req Request) aim() *Aim {
+	o := req.Options
+	if o.FOV == 0 && o.Sensitivity == nil && o.PixelError == 0 {
+		return nil
+	}
+	aim := DefaultAim
+	if o.FOV > 1 {
+		aim.FOV = o.FOV
+	} else if o.FOV >= -1 {
+		aim.FOV = AIM_FOV_DEFAULT + AIM_FOV_RANGE*o.FOV
+	}
+	if o.Sensitivity != nil {
+		aim.Sensitivity = *o.Sensitivity
+	}
+	if o.PixelError > 0 {
+		aim.PixelError = o.PixelError
+	}
+	return &aim
+}
+
+// solver reads the options of a request. Options that would change the answer
+// are an *Error when they cannot be used, rather than being left out.
+func (req Request) solver() (Solver, error) {
+	sv := Solver{Params: ActiveParams()}
+	sv.Options.Hyper = req.Options.Hyper
+	switch req.Options.Estimator {
+	case "", EstimatorLayers, EstimatorPosterior:
+		sv.Options.Estimator = req.Options.Estimator
+	default:
+		return Solver{}, errorf(ErrEstimator, "no estimator %q, expected %s or %s", req.Options.Estimator, EstimatorLayers, EstimatorPosterior)
+	}
+	sv.Options.Seed = req.Options.Seed
+	sv.Options.Aim = req.aim()
+	if err := CheckLayers(req.Options.Layers); err != nil {
+		return Solver{}, err
+	}
+	sv.Options.Layers = req.Options.Layers
+	if req.Options.Selection != "" {
+		sel, err := SelectorByName(req.Options.Selection)
+		if err != nil {
+			return Solver{}, err
+		}
+		sv.Options.Selector = sel
+	}
+	sv.Profile = ProfileFor(req.Options.Version)
+	if len(req.Options.StructureSet) > 0 {
+		p, err := ParseStructureSet(req.Options.StructureSet, sv.Profile, "datapack")
+		if err != nil {
+			return Solver{}, errorf(ErrStructureSet, "structure set: %s", err.Error())
+		}
+		sv.Profile = p
+	}
+	return sv, nil
+}
+
+// NewResponse guesses from the clips of a request. When that fails, the error
+// is also set on the response along with the diagnostics so far. Each request
+// is solved on its own, so requests can be answered concurrently.
+func NewResponse(req Request) (Response, error) {
+	return NewResponseContext(context.Background(), req)
 }
 
-func NewResponse(req Request) Response {
+// NewResponseContext answers the request with the best guess found before the
+// context is done. Tracking and advice are skipped once it is.
+func NewResponseContext(ctx context.Context, req Request) (Response, error) {
 	b, _ := json.Marshal(req)
 	log.Println("request", string(b))
 
 	res := Response{}
-	sess := NewSession()
-	if req.Options.Hyper {
-		sess.Options.Hyper = true
+	diags := make([]Diagnostic, len(req.Clips))
+	fail := func(err error) (Response, error) {
+		log.Println("failed request:", err.Error())
+		res.Method = "reset"
+		res.Diagnostics = diags
+		res.Error = AsError(err)
+		return res, res.Error
+	}
+
+	sv, err := req.solver()
+	if err != nil {
+		return fail(err)
+	}
+	sess := sv.session()
+	if req.Options.Trace {
+		sess.Options.Trace = &Trace{Watch: req.Options.Watch}
+		res.Trace = sess.Options.Trace
+	}
+
+	correction := Correction{}
+	if req.Options.Calibration != nil {
+		correction = req.Options.Calibration.Correction(sess.Profile)
+		sess.Options.Aim = correction.Apply(sess.Options.Aim)
+		res.Correction = &correction
 	}
 
 	log.Println("handling request with", len(req.Clips), "clips")
 
-	sources := map[Throw]string{}
+	sources := map[Throw][]int{}
 	used := []string{}
-	for _, text := range req.Clips {
+	for n, text := range req.Clips {
+		diags[n].Clip = text
 		throw, err := NewThrowFromString(text)
 		if err != nil {
 			log.Println("skipping an invalid clipboard:", err.Error())
 			log.Println(text)
+			diags[n].Reason = ReasonInvalid
 			continue
 		}
-		sources[throw] = text
+		parsed := throw
+		diags[n].Throw = &parsed
+		diags[n].Type = throw.Type.String()
+		throw = correction.Correct(throw)
 
 		if throw.Type == Nether {
 			if res.Portal == nil {
@@ -56,17 +230,29 @@ func NewResponse(req Request) Response {
 				used = append(used, text)
 			}
 			if len(sess.Throws) > 0 {
+				diags[n].Reason = ReasonPortal
 				continue
 			}
 		}
 
-		similar := false
-		for _, t := range sess.Throws {
+		similar := -1
+		for i, t := range sess.Throws {
 			if throw.Similar(t) {
-				similar = true
+				similar = i
 			}
 		}
-		if similar {
+		if similar != -1 {
+			diags[n].Similar = true
+			prev := sess.Throws[similar]
+			if throw.Type != Overworld || prev.Type != Overworld {
+				diags[n].Reason = ReasonSimilar
+				continue
+			}
+			// eyes thrown from the same spot average out each other's noise
+			merged := MergeThrows(prev, throw)
+			sources[merged] = append(sources[prev], n)
+			delete(sources, prev)
+			sess.Throws[similar] = merged
 			continue
 		}
 
@@ -75,31 +261,132 @@ func NewResponse(req Request) Response {
 			throw.Y = 0
 		}
 
+		sources[throw] = []int{n}
 		sess.Throws = append(sess.Throws, throw)
 	}
-	lastThrow := sess.Throws[len(sess.Throws)-1]
-	guess := sess.BestGuess(sess.Throws...)
-	if guess.Method == "reset" {
-		sess.Throws = []Throw{lastThrow}
-		guess = sess.BestGuess(sess.Throws...)
-		log.Println("new session for throw", lastThrow)
+	throws := sess.Throws
+	if len(throws) == 0 {
+		return fail(ErrNoThrows)
+	}
+	lastThrow := throws[len(throws)-1]
+	guess, err := sess.BestGuessContext(ctx, throws...)
+	// throws that all disagree may still be following different strongholds
+	agreed := err == nil
+	if !agreed && (!errors.Is(err, ErrNoScore) || len(throws) < 2) {
+		return fail(err)
+	}
+	dropped := guess.Dropped
+	if (!agreed || len(guess.Rejected) > 0) && !sess.stopped() {
+		// the eye may have switched to another stronghold along the way
+		hyps, terr := sess.Track(throws...)
+		if terr != nil && !sess.stopped() {
+			return fail(terr)
+		}
+		if terr != nil {
+			// out of time, so the guess stands untracked
+			log.Println("skipping tracking:", terr.Error())
+			guess.Partial = true
+		} else {
+			current := hyps[len(hyps)-1]
+			res.Crossed = Crossed(hyps)
+			if !agreed || res.Crossed {
+				log.Println("following", len(current.Throws), "throws to", Chunk(current.Chunk))
+				guess, agreed = current.Guess, true
+				sess.Throws = current.Throws
+				for _, h := range hyps[:len(hyps)-1] {
+					for _, t := range h.Throws {
+						for _, clip := range sources[t] {
+							diags[clip].Reason = ReasonElsewhere
+						}
+					}
+				}
+			} else if _, err := sess.subsetGuess(guess.Used); err != nil && !sess.stopped() {
+				// tracking moved the session on, so the guess's own throws
+				// are scored again to describe it
+				return fail(err)
+			}
+			for n, h := range hyps {
+				for _, t := range h.Throws {
+					hyps[n].Clips = append(hyps[n].Clips, sources[t]...)
+				}
+			}
+			res.Hypotheses = hyps
+		}
+	}
+	if !agreed {
+		return fail(err)
+	}
+	for _, t := range guess.Rejected {
+		for _, clip := range sources[t] {
+			diags[clip].Reason = ReasonRejected
+		}
+	}
+	for _, t := range dropped {
+		for _, clip := range sources[t] {
+			diags[clip].Reason = ReasonDropped
+		}
 	}
 	for _, t := range guess.Used {
-		used = append(used, sources[t])
+		for _, clip := range sources[t] {
+			used = append(used, req.Clips[clip])
+		}
+	}
+	for t, clips := range sources {
+		if t.Type != Overworld {
+			continue
+		}
+		for _, clip := range clips {
+			diags[clip].Uncertainty = t.Spread(YAW_SIGMA * sess.Options.Aim.Scale())
+		}
+	}
+	for n, d := range diags {
+		if d.Throw == nil || d.Throw.Type != Overworld {
+			continue
+		}
+		residual := degsFromRads(sess.Profile.Angle(guess.Chunk, d.Throw.A, d.Throw.X, d.Throw.Y))
+		diags[n].Residual = &residual
 	}
-	x, y := Chunk(guess.Chunk).Staircase()
+	x, y := sess.Profile.Staircase(guess.Chunk)
 
 	res.Keep = used
+	res.Diagnostics = diags
+	res.Candidates = guess.Candidates
+	if sess.stopped() {
+		guess.Partial = true
+	} else if advice, ok := sess.NextThrow(); ok {
+		res.Advice = &advice
+	}
+	if req.Options.Confirmed != nil {
+		preds, err := sess.Profile.PredictRing(*req.Options.Confirmed, req.Options.RingAbove)
+		if err != nil {
+			log.Println("skipping ring prediction:", err.Error())
+		}
+		res.Predictions = preds
+	}
 	res.Chunk = &guess.Chunk
 	res.Coords = &[2]int{x, y}
 	res.Player = &[2]int{int(lastThrow.X), int(lastThrow.Y)}
 	res.Confidence = guess.Confidence
 	res.Method = guess.Method
+	res.Version = sess.Profile.Name
+	res.Params = sess.params().Version
+	res.Estimator = guess.Estimator
+	res.Selection = guess.Selection
+	res.Probability = guess.Probability
+	res.Partial = guess.Partial
+	if guess.Fit != nil {
+		res.Uncertainty = &guess.Fit.Ellipse
+		for _, r := range guess.Fit.Residuals {
+			res.Residuals = append(res.Residuals, degsFromRads(r))
+		}
+	}
 
-	c, _ := json.Marshal(res)
+	logged := res
+	logged.Trace = nil
+	c, _ := json.Marshal(logged)
 	log.Println("response", string(c))
 
-	return res
+	return res, nil
 }
 
 // /execute in minecraft:overworld run tp @s -214.79 104.61 386.16 76.50 -32.40
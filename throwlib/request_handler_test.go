@@ -27,8 +27,43 @@ func TestParsing(t *testing.T) {
 
 func TestBlind(t *testing.T) {
 	throw, _ := NewThrowFromString(`/execute in minecraft:overworld run tp @s -146.06 131.53 457.92 668.39 -10.35`)
-	DEBUG = true
-	guess := NewSession().BestGuess(throw)
+	sess := NewSession()
+	sess.Options.Trace = &Trace{Log: true}
+	guess := guessOf(sess, throw)
 	x, y := Chunk(guess.Chunk).Center()
 	t.Logf("%#v blind to %d %d", throw, x, y)
 }
+
+func TestDiagnostics(t *testing.T) {
+	req := Request{Clips: []string{
+		"/execute in minecraft:overworld run tp @s 294.96 116.93 -486.85 -499.05 -25.35",
+		"/execute in minecraft:overworld run tp @s 296.96 116.93 -486.85 -499.15 -25.35",
+		"not a clip",
+		"/execute in minecraft:overworld run tp @s 362.90 116.93 -669.03 -493.95 -31.65",
+	}}
+	res, err := NewResponse(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res.Diagnostics) != len(req.Clips) {
+		t.Fatalf("expected %d diagnostics, got %d", len(req.Clips), len(res.Diagnostics))
+	}
+	if !res.Diagnostics[1].Similar {
+		t.Errorf("expected second clip to be similar, got %#v", res.Diagnostics[1])
+	}
+	if res.Diagnostics[2].Reason != ReasonInvalid {
+		t.Errorf("expected third clip to be invalid, got %#v", res.Diagnostics[2])
+	}
+	for _, n := range []int{0, 1, 3} {
+		d := res.Diagnostics[n]
+		if d.Reason != "" || d.Residual == nil {
+			t.Errorf("expected clip %d to be used with a residual, got %#v", n, d)
+		}
+	}
+	if len(res.Keep) != 3 {
+		t.Errorf("expected the similar clip to be kept, got %d clips", len(res.Keep))
+	}
+	if u := res.Diagnostics[1].Uncertainty; u != res.Diagnostics[0].Uncertainty || u >= res.Diagnostics[3].Uncertainty {
+		t.Errorf("expected averaged clips to share a smaller uncertainty, got %.3f and %.3f", u, res.Diagnostics[3].Uncertainty)
+	}
+}
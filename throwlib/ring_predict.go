@@ -0,0 +1,90 @@
+package throwlib
+
+import (
+	"fmt"
+	"math"
+)
+
+// how far the game may move a stronghold off its spoke looking for a biome
+const SNAP_BLOCKS = 112
+
+// Spoke is a line from origin that a stronghold sits on. Angle is the
+// Minecraft yaw facing out from origin, and Width how many degrees either
+// side of it the stronghold can end up. Positions are in blocks.
+type Spoke struct {
+	Angle float64 `json:"angle"`
+	Width float64 `json:"width"`
+
+	Near   [2]int `json:"near"`
+	Center [2]int `json:"center"`
+	Far    [2]int `json:"far"`
+
+	NetherNear   [2]int `json:"nether_near"`
+	NetherCenter [2]int `json:"nether_center"`
+	NetherFar    [2]int `json:"nether_far"`
+}
+
+// RingPrediction is where the strongholds of one ring can be. Band is the
+// distance from origin in blocks, Spacing the degrees between spokes, and
+// Spokes is empty when the ring's rotation is not known.
+type RingPrediction struct {
+	Ring    int     `json:"ring"`
+	Band    [2]int  `json:"band"`
+	Count   int     `json:"count"`
+	Spacing float64 `json:"spacing"`
+	Spokes  []Spoke `json:"spokes,omitempty"`
+}
+
+// PredictRing gives the other strongholds in the ring of a confirmed one.
+// Each ring is rotated randomly, so the ring above only gets its band.
+func (p *Profile) PredictRing(confirmed Chunk, above bool) ([]RingPrediction, error) {
+	ring := p.RingID(confirmed)
+	if ring == -1 {
+		return nil, fmt.Errorf("%s is not in any ring", confirmed)
+	}
+
+	x, y := confirmed.Center()
+	start := math.Atan2(-float64(x), float64(y))
+	inc := math.Pi * 2 / float64(p.Counts[ring])
+
+	pred := p.ringBand(ring)
+	for n := 1; n < p.Counts[ring]; n++ {
+		pred.Spokes = append(pred.Spokes, p.spoke(ring, wrapRads(start+inc*float64(n))))
+	}
+	preds := []RingPrediction{pred}
+
+	if above && ring+1 < len(p.Rings) {
+		preds = append(preds, p.ringBand(ring+1))
+	}
+	return preds, nil
+}
+
+func (p *Profile) ringBand(ring int) RingPrediction {
+	return RingPrediction{
+		Ring:    ring,
+		Band:    p.Rings[ring],
+		Count:   p.Counts[ring],
+		Spacing: 360 / float64(p.Counts[ring]),
+	}
+}
+
+func (p *Profile) spoke(ring int, angle float64) Spoke {
+	dx, dy := -math.Sin(angle), math.Cos(angle)
+	at := func(d float64) [2]int {
+		return [2]int{int(dx * d), int(dy * d)}
+	}
+	nether := func(pos [2]int) [2]int {
+		return [2]int{pos[0] / 8, pos[1] / 8}
+	}
+
+	minDist, maxDist := float64(p.Rings[ring][0]), float64(p.Rings[ring][1])
+	s := Spoke{
+		Angle:  degsFromRads(angle),
+		Width:  degsFromRads(math.Atan2(SNAP_BLOCKS, minDist)),
+		Near:   at(minDist),
+		Center: at((minDist + maxDist) / 2),
+		Far:    at(maxDist),
+	}
+	s.NetherNear, s.NetherCenter, s.NetherFar = nether(s.Near), nether(s.Center), nether(s.Far)
+	return s
+}
@@ -0,0 +1,39 @@
+package throwlib
+
+import (
+	"math"
+	"testing"
+)
+
+func TestPredictRing(t *testing.T) {
+	for seed := int64(0); seed < 10; seed++ {
+		strongholds := Java116.Strongholds(seed)
+		// the second ring is the six strongholds after the first three
+		ring := strongholds[3:9]
+		preds, err := Java116.PredictRing(ring[0], true)
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+		if len(preds) != 2 || len(preds[0].Spokes) != 5 || len(preds[1].Spokes) != 0 {
+			t.Fatalf("seed %d predicted %#v", seed, preds)
+		}
+		for _, other := range ring[1:] {
+			x, y := other.Center()
+			angle := degsFromRads(math.Atan2(-float64(x), float64(y)))
+			matched := false
+			for _, s := range preds[0].Spokes {
+				off := math.Abs(degsFromRads(wrapRads(radsFromDegs(angle - s.Angle))))
+				if off <= s.Width {
+					matched = true
+				}
+			}
+			if !matched {
+				t.Errorf("seed %d stronghold %s at %.1f° is on no predicted spoke", seed, other, angle)
+			}
+		}
+	}
+
+	if _, err := Java116.PredictRing(Chunk{0, 0}, false); err == nil {
+		t.Errorf("expected an error for a chunk outside the rings")
+	}
+}
@@ -0,0 +1,86 @@
+package throwlib
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// SeedMatch is how well one seed explains a set of throws. Error is the root
+// mean square yaw error in degrees, and Stronghold is where the last throw
+// points on that seed.
+type SeedMatch struct {
+	Seed       int64   `json:"seed"`
+	Stronghold [2]int  `json:"stronghold"`
+	Coords     [2]int  `json:"coords"`
+	Error      float64 `json:"error"`
+}
+
+// ReadSeeds reads one numeric seed per line, skipping blanks and # comments.
+func ReadSeeds(r io.Reader) ([]int64, error) {
+	seeds := []int64{}
+	scanner := bufio.NewScanner(r)
+	line := 0
+	for scanner.Scan() {
+		line++
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		seed, err := strconv.ParseInt(text, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: invalid seed %q", line, text)
+		}
+		seeds = append(seeds, seed)
+	}
+	return seeds, scanner.Err()
+}
+
+// RankSeeds orders seeds by how closely each aimed throw points at where the
+// stronghold nearest to it on that seed could have snapped to, keeping the
+// best top. Only forked profiles place strongholds from a seed.
+func (p *Profile) RankSeeds(seeds []int64, ts []Throw, top int) ([]SeedMatch, error) {
+	if !p.Forked {
+		return nil, fmt.Errorf("seeds cannot place strongholds for %s", p.Name)
+	}
+	aimed := []Throw{}
+	for _, t := range ts {
+		if t.Type == Overworld {
+			aimed = append(aimed, t)
+		}
+	}
+	if len(aimed) == 0 {
+		return nil, fmt.Errorf("no aimed throws to rank seeds with")
+	}
+
+	matches := make([]SeedMatch, 0, len(seeds))
+	for _, seed := range seeds {
+		strongholds := p.Strongholds(seed)
+		sum := 0.0
+		var target Chunk
+		for _, t := range aimed {
+			target = closestChunk(strongholds, t.X, t.Y)
+			delta := degsFromRads(p.seedAngle(target, t))
+			sum += delta * delta
+		}
+		x, y := p.Staircase(target)
+		matches = append(matches, SeedMatch{
+			Seed:       seed,
+			Stronghold: target,
+			Coords:     [2]int{x, y},
+			Error:      math.Sqrt(sum / float64(len(aimed))),
+		})
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		return matches[i].Error < matches[j].Error
+	})
+	if top > 0 && len(matches) > top {
+		matches = matches[:top]
+	}
+	return matches, nil
+}
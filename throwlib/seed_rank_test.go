@@ -0,0 +1,43 @@
+package throwlib
+
+import (
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestRankSeeds(t *testing.T) {
+	seeds, err := ReadSeeds(strings.NewReader("# candidates\n1\n\n2\n3\n-4\n5\n"))
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if len(seeds) != 5 {
+		t.Fatalf("read %d seeds", len(seeds))
+	}
+
+	goal := JavaCurrent.ClosestStronghold(3, NewBlindThrow(100, 100))
+	throws := []Throw{}
+	for _, pos := range [][2]float64{{100, 100}, {180, 40}} {
+		gx, gy := goal.Center()
+		a := degsFromRads(math.Atan2(pos[0]-float64(gx), float64(gy)-pos[1]))
+		throws = append(throws, NewThrow(pos[0], pos[1], a))
+	}
+
+	matches, err := JavaCurrent.RankSeeds(seeds, throws, 2)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if len(matches) != 2 || matches[0].Seed != 3 {
+		t.Fatalf("expected seed 3 first, got %#v", matches)
+	}
+	if Chunk(matches[0].Stronghold) != goal {
+		t.Errorf("seed 3 predicted %v, not %s", matches[0].Stronghold, goal)
+	}
+
+	if _, err := Java116.RankSeeds(seeds, throws, 2); err == nil {
+		t.Errorf("expected seeds to be refused before 1.19")
+	}
+	if _, err := ReadSeeds(strings.NewReader("12\nnot a seed\n")); err == nil {
+		t.Errorf("expected an invalid seed error")
+	}
+}
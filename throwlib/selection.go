@@ -0,0 +1,121 @@
+package throwlib
+
+import "sort"
+
+const (
+	SelectClosest = "closest"
+	SelectHighest = "highest"
+	SelectTravel  = "travel"
+)
+
+// how many of the best scored chunks the travel strategy weighs up
+const TRAVEL_CANDIDATES = 64
+
+// Selector picks the chunk to send the player to. Chunks come in a stable
+// order with their weights, and cx, cy is the center of the cluster the player
+// is nearest.
+type Selector interface {
+	Name() string
+	Select(chunks []Chunk, weights map[Chunk]float64, cx, cy float64) Chunk
+}
+
+// ClosestSelector picks the chunk nearest the cluster center for its weight.
+type ClosestSelector struct{}
+
+func (ClosestSelector) Name() string { return SelectClosest }
+
+func (ClosestSelector) Select(chunks []Chunk, weights map[Chunk]float64, cx, cy float64) Chunk {
+	closest := chunks[0]
+	closestDistance := closest.Dist(cx, cy) / weights[closest]
+	for _, c := range chunks {
+		dist := c.Dist(cx, cy) / weights[c]
+		if dist < closestDistance {
+			closest = c
+			closestDistance = dist
+		}
+	}
+	return closest
+}
+
+// HighestSelector picks the single most likely chunk.
+type HighestSelector struct{}
+
+func (HighestSelector) Name() string { return SelectHighest }
+
+func (HighestSelector) Select(chunks []Chunk, weights map[Chunk]float64, cx, cy float64) Chunk {
+	highest := chunks[0]
+	highestScore := 0.0
+	for _, c := range chunks {
+		if weights[c] > highestScore {
+			highestScore = weights[c]
+			highest = c
+		}
+	}
+	return highest
+}
+
+// TravelSelector picks the chunk with the least expected distance left to the
+// stronghold once the player gets there, weighing every chunk by its score.
+// Only the best scored chunks are tried, since the answer is always near them.
+type TravelSelector struct{}
+
+func (TravelSelector) Name() string { return SelectTravel }
+
+func (TravelSelector) Select(chunks []Chunk, weights map[Chunk]float64, cx, cy float64) Chunk {
+	tried := append([]Chunk{}, chunks...)
+	sort.SliceStable(tried, func(i, j int) bool {
+		return weights[tried[i]] > weights[tried[j]]
+	})
+	if len(tried) > TRAVEL_CANDIDATES {
+		tried = tried[:TRAVEL_CANDIDATES]
+	}
+
+	best := tried[0]
+	bestTravel := 0.0
+	for n, c := range tried {
+		travel := ExpectedTravel(c, chunks, weights)
+		if n == 0 || travel < bestTravel {
+			best = c
+			bestTravel = travel
+		}
+	}
+	return best
+}
+
+// ExpectedTravel is the average distance from a chunk to the stronghold, with
+// the stronghold in each chunk as likely as its weight.
+func ExpectedTravel(from Chunk, chunks []Chunk, weights map[Chunk]float64) float64 {
+	travel, total := 0.0, 0.0
+	for _, c := range chunks {
+		w := weights[c]
+		if w <= 0 {
+			continue
+		}
+		travel += w * from.ChunkDist(c)
+		total += w
+	}
+	if total == 0 {
+		return 0
+	}
+	return travel / total
+}
+
+var selectors = []Selector{ClosestSelector{}, HighestSelector{}, TravelSelector{}}
+
+// SelectorNames lists the built in strategies.
+func SelectorNames() []string {
+	names := []string{}
+	for _, sel := range selectors {
+		names = append(names, sel.Name())
+	}
+	return names
+}
+
+func SelectorByName(name string) (Selector, error) {
+	for _, sel := range selectors {
+		if sel.Name() == name {
+			return sel, nil
+		}
+	}
+	return nil, errorf(ErrSelection, "unknown selection strategy %q", name)
+}
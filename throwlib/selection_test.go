@@ -0,0 +1,87 @@
+package throwlib
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestTravelSelector(t *testing.T) {
+	a, b, c := Chunk{0, 0}, Chunk{10, 0}, Chunk{20, 0}
+	chunks := []Chunk{a, b, c}
+	weights := map[Chunk]float64{a: 3, b: 2, c: 2}
+
+	if got := (HighestSelector{}).Select(chunks, weights, 0, 0); got != a {
+		t.Errorf("highest picked %s", got)
+	}
+	if got := (TravelSelector{}).Select(chunks, weights, 0, 0); got != b {
+		t.Errorf("travel picked %s, expected %s", got, b)
+	}
+	if travel := ExpectedTravel(b, chunks, weights); travel != 160*5.0/7 {
+		t.Errorf("expected travel from the middle is %.1f", travel)
+	}
+}
+
+func TestSelectionAccuracy(t *testing.T) {
+	overall := map[string]int{}
+	for _, name := range SelectorNames() {
+		sel, err := SelectorByName(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		distances := []int{0, 0, 0}
+		totals := []int{0, 0, 0}
+		for _, test := range progressionTests {
+			throws := test.throws
+			if len(throws) > 2 {
+				throws = throws[:2]
+			}
+			for num := range throws {
+				sess := NewSession()
+				sess.Options.Selector = sel
+				guess, err := sess.BestGuess(throws[:num+1]...)
+				if errors.Is(err, ErrNoScore) {
+					continue
+				}
+				if err != nil {
+					t.Fatal(err)
+				}
+				if guess.Selection != name {
+					t.Errorf("guess selected by %s, expected %s", guess.Selection, name)
+				}
+				distances[num+1] += int(Chunk(guess.Chunk).ChunkDist(test.goal))
+				totals[num+1]++
+			}
+		}
+		for throw, score := range distances {
+			if totals[throw] == 0 {
+				continue
+			}
+			t.Logf("average %s throw %d accuracy for %d samples: %d blocks", name, throw, totals[throw], score/totals[throw])
+			overall[name] += score / totals[throw]
+		}
+		if distances[2]/totals[2] >= distances[1]/totals[1] {
+			t.Errorf("%s is no closer after a second throw", name)
+		}
+	}
+	// the default strategy is the default for being the most accurate
+	for name, score := range overall {
+		if score < overall[SELECTION_METHOD] {
+			t.Errorf("%s averages %d blocks over both throws, under the default %s at %d", name, score, SELECTION_METHOD, overall[SELECTION_METHOD])
+		}
+	}
+}
+
+func TestRequestSelection(t *testing.T) {
+	req := Request{Clips: []string{"/execute in minecraft:overworld run tp @s 294.96 116.93 -486.85 -499.05 -25.35"}}
+	if res, err := NewResponse(req); err != nil || res.Selection != SELECTION_METHOD {
+		t.Errorf("default selection is %q", res.Selection)
+	}
+	req.Options.Selection = SelectTravel
+	if res, err := NewResponse(req); err != nil || res.Selection != SelectTravel {
+		t.Errorf("asked for travel, selected by %q", res.Selection)
+	}
+	req.Options.Selection = "nearest"
+	if res, err := NewResponse(req); !errors.Is(err, ErrSelection) || res.Error == nil {
+		t.Errorf("expected an unknown selection to fail, got %v", err)
+	}
+}
@@ -0,0 +1,79 @@
+package throwlib
+
+import "context"
+
+// Solver solves throws without keeping anything between solves, so one solver
+// can serve any number of goroutines at once. The zero Solver uses the default
+// profile and the params active when each solve starts.
+//
+// A trace in the options only says what to record: each solve records into a
+// trace of its own, returned with its result. Progress is called from every
+// goroutine solving, so a shared solver needs one safe for that.
+type Solver struct {
+	Profile *Profile
+	Params  *Params
+	// a layer set to always score with, instead of choosing from the throws
+	LayerSet *LayerSet
+	Options  SolveOptions
+	// also work out where to throw next, which takes longer than the guess
+	Advise bool
+}
+
+// Result is what a solve found. Its maps are made for each solve, so they
+// belong to the caller.
+type Result struct {
+	Guess
+	LayerSet  string            `json:"layer_set"`
+	Scores    map[Chunk]int     `json:"-"`
+	Posterior map[Chunk]float64 `json:"-"`
+	Advice    *Advice           `json:"advice,omitempty"`
+	Trace     *Trace            `json:"trace,omitempty"`
+}
+
+// Solve is a one off solve with the given options.
+func Solve(opts SolveOptions, ts ...Throw) (Result, error) {
+	return Solver{Options: opts}.Solve(ts...)
+}
+
+func (sv Solver) Solve(ts ...Throw) (Result, error) {
+	return sv.SolveContext(context.Background(), ts...)
+}
+
+// SolveContext solves until the context is done, returning the best guess so
+// far marked Partial if it had to stop early.
+func (sv Solver) SolveContext(ctx context.Context, ts ...Throw) (Result, error) {
+	sess := sv.session()
+	g, err := sess.BestGuessContext(ctx, append([]Throw{}, ts...)...)
+	if err != nil {
+		return Result{}, err
+	}
+	res := Result{
+		Guess:     g,
+		LayerSet:  sess.LayerSet.Code,
+		Scores:    sess.Scores,
+		Posterior: sess.Posterior,
+		Trace:     sess.Options.Trace,
+	}
+	if sv.Advise && !sess.stopped() {
+		if advice, ok := sess.NextThrow(); ok {
+			res.Advice = &advice
+		}
+	}
+	return res, nil
+}
+
+// session is a fresh session for one solve.
+func (sv Solver) session() *Session {
+	sess := NewSession()
+	if sv.LayerSet != nil {
+		ls := *sv.LayerSet
+		sess.CustomLayer = &ls
+	}
+	if sv.Params != nil {
+		sess.Params = sv.Params
+	}
+	sess.Profile = sv.Profile
+	sess.Options = sv.Options
+	sess.Options.Trace = sv.Options.Trace.fresh()
+	return sess
+}
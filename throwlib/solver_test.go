@@ -0,0 +1,131 @@
+package throwlib
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestSolverMatchesSession(t *testing.T) {
+	for _, test := range progressionTests[:5] {
+		want := guessOf(NewSession(), test.throws[:2]...)
+		got, err := Solver{}.Solve(test.throws[:2]...)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got.Chunk != want.Chunk || got.Confidence != want.Confidence {
+			t.Errorf("solver guessed %v at %d, session %v at %d", got.Chunk, got.Confidence, want.Chunk, want.Confidence)
+		}
+		if len(got.Scores) == 0 || got.LayerSet == "" {
+			t.Errorf("expected the result to carry its scores and layer set")
+		}
+	}
+}
+
+func TestConcurrentSolves(t *testing.T) {
+	tests := progressionTests[:8]
+	solvers := []Solver{
+		{},
+		{Options: SolveOptions{Estimator: EstimatorPosterior}},
+		{Options: SolveOptions{Selector: TravelSelector{}}, Advise: true},
+	}
+
+	want := make([][]Result, len(solvers))
+	for n, sv := range solvers {
+		for _, test := range tests {
+			res, err := sv.Solve(test.throws...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			want[n] = append(want[n], res)
+		}
+	}
+
+	var wg sync.WaitGroup
+	errs := make(chan string, len(solvers)*len(tests)*4)
+	// reloading the same params must not disturb solves underway
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for n := 0; n < 100; n++ {
+			UseParams(&DefaultParams)
+		}
+	}()
+	for round := 0; round < 4; round++ {
+		for n, sv := range solvers {
+			for i, test := range tests {
+				wg.Add(1)
+				go func(n, i int, sv Solver, throws []Throw) {
+					defer wg.Done()
+					res, err := sv.Solve(throws...)
+					if err != nil {
+						errs <- err.Error()
+						return
+					}
+					if res.Chunk != want[n][i].Chunk || res.Confidence != want[n][i].Confidence {
+						errs <- "a concurrent solve guessed differently"
+					}
+				}(n, i, sv, test.throws)
+			}
+		}
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Error(err)
+	}
+}
+
+func TestConcurrentResponses(t *testing.T) {
+	req := Request{Clips: []string{
+		"/execute in minecraft:overworld run tp @s 294.96 116.93 -486.85 -499.05 -25.35",
+		"/execute in minecraft:overworld run tp @s 362.90 116.93 -669.03 -493.95 -31.65",
+	}}
+	req.Options.Trace = true
+	want, err := NewResponse(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var wg sync.WaitGroup
+	for n := 0; n < 8; n++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			res, err := NewResponse(req)
+			if err != nil || *res.Chunk != *want.Chunk {
+				t.Errorf("expected every response to guess %v", *want.Chunk)
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func TestSolverTraces(t *testing.T) {
+	sv := Solver{Options: SolveOptions{Trace: &Trace{}}}
+	tests := progressionTests[:6]
+	var wg sync.WaitGroup
+	for _, test := range tests {
+		wg.Add(1)
+		go func(throws []Throw) {
+			defer wg.Done()
+			res, err := sv.Solve(throws...)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			if res.Trace == nil || res.Trace == sv.Options.Trace {
+				t.Errorf("expected a trace of the solve's own")
+				return
+			}
+			for _, ct := range res.Trace.Chunks {
+				if ct.Chosen && ct.Chunk != res.Chunk {
+					t.Errorf("trace chose %v for a solve that guessed %v", ct.Chunk, res.Chunk)
+				}
+			}
+		}(test.throws)
+	}
+	wg.Wait()
+	if len(sv.Options.Trace.Chunks) > 0 || len(sv.Options.Trace.Events) > 0 {
+		t.Errorf("expected the solver's trace to record nothing")
+	}
+}
@@ -0,0 +1,117 @@
+package throwlib
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"strings"
+)
+
+const CONCENTRIC_RINGS = "minecraft:concentric_rings"
+
+// StructureSet is the part of a datapack structure_set file that places
+// strongholds.
+type StructureSet struct {
+	Structures []struct {
+		Structure string `json:"structure"`
+		Weight    int    `json:"weight"`
+	} `json:"structures"`
+	Placement struct {
+		Type     string `json:"type"`
+		Distance int    `json:"distance"`
+		Spread   int    `json:"spread"`
+		Count    int    `json:"count"`
+		// a biome tag, or a list of biome ids
+		PreferredBiomes json.RawMessage `json:"preferred_biomes"`
+	} `json:"placement"`
+}
+
+// NewConcentricProfile derives ring bounds and per-ring counts the same way
+// the game spreads concentric_rings placements, keeping base's eye targeting.
+func NewConcentricProfile(base *Profile, name string, distance, spread, count int) Profile {
+	p := *base
+	p.Name = name
+	p.Distance = distance
+	p.Spread = spread
+	p.Total = count
+	p.Legacy = false
+	p.Simulated = false
+	p.RingSpacing = 6
+	p.Rings = nil
+	p.Counts = nil
+
+	inRing := spread
+	for placed, ring := 0, 0; placed < count; ring++ {
+		if inRing > count-placed {
+			inRing = count - placed
+		}
+		// each stronghold lands within 1.25 distances of its ring's center
+		center := (4*distance + distance*ring*p.RingSpacing) * 16
+		buffer := distance * 20
+		p.Rings = append(p.Rings, [2]int{center - buffer, center + buffer})
+		p.Counts = append(p.Counts, inRing)
+		placed += inRing
+		inRing += 2 * inRing / (ring + 2)
+	}
+	return p
+}
+
+// LoadStructureSet reads a structure_set file into a profile with the given name.
+func LoadStructureSet(r io.Reader, base *Profile, name string) (*Profile, error) {
+	b, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	return ParseStructureSet(b, base, name)
+}
+
+// ParseStructureSet reads a structure_set file into a profile. Which biomes a
+// datapack prefers is not modelled, as the world's biomes are not known: any
+// preferred biomes move strongholds as far as vanilla biome snapping can, and
+// only an empty list, which never moves them, changes the displacement.
+func ParseStructureSet(b []byte, base *Profile, name string) (*Profile, error) {
+	set := StructureSet{}
+	if err := json.Unmarshal(b, &set); err != nil {
+		return nil, err
+	}
+	pl := set.Placement
+	kind := pl.Type
+	if !strings.Contains(kind, ":") {
+		kind = "minecraft:" + kind
+	}
+	if kind != CONCENTRIC_RINGS {
+		return nil, fmt.Errorf("unsupported placement type %q", pl.Type)
+	}
+	if pl.Distance <= 0 || pl.Spread <= 0 || pl.Count <= 0 {
+		return nil, fmt.Errorf("invalid concentric rings, distance %d spread %d count %d", pl.Distance, pl.Spread, pl.Count)
+	}
+
+	snaps, err := snapsToBiomes(pl.PreferredBiomes)
+	if err != nil {
+		return nil, err
+	}
+
+	p := NewConcentricProfile(base, name, pl.Distance, pl.Spread, pl.Count)
+	if !snaps {
+		p.Displacement = Displacement{}
+	}
+	return &p, nil
+}
+
+// snapsToBiomes is whether strongholds move to preferred biomes written as a
+// tag or id, or a list of ids, which they cannot when the list is empty.
+func snapsToBiomes(raw json.RawMessage) (bool, error) {
+	if len(raw) == 0 {
+		return true, nil
+	}
+	var one string
+	if err := json.Unmarshal(raw, &one); err == nil {
+		return true, nil
+	}
+	var list []string
+	if err := json.Unmarshal(raw, &list); err != nil {
+		return false, fmt.Errorf("preferred_biomes is neither a biome tag nor a list of biomes: %s", raw)
+	}
+	return len(list) > 0, nil
+}
@@ -0,0 +1,82 @@
+package throwlib
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const vanillaStrongholds = `{
+  "structures": [{"structure": "minecraft:stronghold", "weight": 1}],
+  "placement": {
+    "type": "minecraft:concentric_rings",
+    "distance": 32,
+    "spread": 3,
+    "count": 128,
+    "preferred_biomes": "#minecraft:stronghold_biased_to",
+    "salt": 0
+  }
+}`
+
+func TestVanillaStructureSet(t *testing.T) {
+	p, err := LoadStructureSet(strings.NewReader(vanillaStrongholds), DefaultProfile, "vanilla")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if !reflect.DeepEqual(p.Rings, Java116.Rings) {
+		t.Errorf("rings %v != %v", p.Rings, Java116.Rings)
+	}
+	if !reflect.DeepEqual(p.Counts, Java116.Counts) {
+		t.Errorf("counts %v != %v", p.Counts, Java116.Counts)
+	}
+}
+
+func TestCustomStructureSet(t *testing.T) {
+	custom := strings.Replace(vanillaStrongholds, `"distance": 32`, `"distance": 64`, 1)
+	custom = strings.Replace(custom, `"count": 128`, `"count": 40`, 1)
+	p, err := ParseStructureSet([]byte(custom), DefaultProfile, "custom")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	for seed := int64(0); seed < 20; seed++ {
+		for _, pos := range p.GenStrongholds(seed) {
+			if p.RingID(ChunkFromCenter(pos[0], pos[1])) == -1 {
+				t.Errorf("seed %d stronghold %v is outside every ring", seed, pos)
+			}
+		}
+	}
+
+	spread := strings.Replace(vanillaStrongholds, "concentric_rings", "random_spread", 1)
+	if _, err := ParseStructureSet([]byte(spread), DefaultProfile, "spread"); err == nil {
+		t.Errorf("random_spread placement should not load")
+	}
+}
+
+func TestBiomeListStructureSet(t *testing.T) {
+	listed := strings.Replace(vanillaStrongholds, `"#minecraft:stronghold_biased_to"`, `["minecraft:plains", "minecraft:forest"]`, 1)
+	if _, err := ParseStructureSet([]byte(listed), DefaultProfile, "listed"); err != nil {
+		t.Errorf("a list of preferred biomes should load: %s", err.Error())
+	}
+	none := strings.Replace(vanillaStrongholds, `"#minecraft:stronghold_biased_to"`, `[]`, 1)
+	p, err := ParseStructureSet([]byte(none), DefaultProfile, "none")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p.Displacement.Radius != 0 || DefaultProfile.Displacement.Radius == 0 {
+		t.Errorf("strongholds without preferred biomes cannot be displaced, got %v", p.Displacement)
+	}
+	numbered := strings.Replace(vanillaStrongholds, `"#minecraft:stronghold_biased_to"`, `3`, 1)
+	if _, err := ParseStructureSet([]byte(numbered), DefaultProfile, "numbered"); err == nil {
+		t.Errorf("a number is not a preferred biome")
+	}
+}
+
+func TestStructureSetRequest(t *testing.T) {
+	req := Request{Clips: []string{"/execute in minecraft:overworld run tp @s 294.96 116.93 -486.85 -499.05 -25.35"}}
+	req.Options.StructureSet = []byte(strings.Replace(vanillaStrongholds, "concentric_rings", "random_spread", 1))
+	res, err := NewResponse(req)
+	if !errors.Is(err, ErrStructureSet) || res.Error == nil || res.Error.Code != ErrCodeStructureSet {
+		t.Errorf("expected a structure set error instead of vanilla rings, got %v", err)
+	}
+}
@@ -0,0 +1,246 @@
+package throwlib
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// how many events a trace keeps, dropping the rest
+const TRACE_EVENTS = 200
+
+// Trace records how one solve went: the layer scores of watched chunks and the
+// chosen one, why chunks were rejected, the clusters of the last clustering,
+// and how long each stage took. A solve may score many subsets of its throws,
+// and all but Subsets, Total and Events describe only the last one scored,
+// which the search leaves as the one guessed from unless it ran out of time.
+// A nil trace records nothing, so solves pass it along without checking.
+type Trace struct {
+	// chunks to record scores for, besides the chosen one
+	Watch []Chunk `json:"watch,omitempty"`
+	// also write events to the log as they happen
+	Log bool `json:"-"`
+
+	Chunks   []*ChunkTrace      `json:"chunks,omitempty"`
+	Clusters []ClusterTrace     `json:"clusters,omitempty"`
+	Rejected map[string]int     `json:"rejected,omitempty"`
+	Timings  map[string]float64 `json:"timings_ms,omitempty"`
+	Events   []string           `json:"events,omitempty"`
+
+	// subsets of the throws scored, and the time taken over all of them
+	Subsets int     `json:"subsets"`
+	Total   float64 `json:"total_ms"`
+
+	lock sync.Mutex
+}
+
+// ChunkTrace is how a chunk was last scored. Rejected names the layer or
+// check that ruled it out.
+type ChunkTrace struct {
+	Chunk    Chunk          `json:"chunk"`
+	Throws   int            `json:"throws"`
+	Layers   map[string]int `json:"layers"`
+	Score    int            `json:"score"`
+	Rejected string         `json:"rejected,omitempty"`
+	Chosen   bool           `json:"chosen,omitempty"`
+}
+
+type ClusterTrace struct {
+	Center [2]float64 `json:"center"`
+	Size   int        `json:"size"`
+	Score  int        `json:"score"`
+	Ring   int        `json:"ring"`
+}
+
+// fresh is an empty trace watching the same chunks, for one solve to record
+// into.
+func (tr *Trace) fresh() *Trace {
+	if tr == nil {
+		return nil
+	}
+	return &Trace{Watch: tr.Watch, Log: tr.Log}
+}
+
+// Watching is whether scores for the chunk are recorded.
+func (tr *Trace) Watching(c Chunk) bool {
+	return tr != nil && tr.watching(c)
+}
+
+// Logging is whether the solve should also be written to the log.
+func (tr *Trace) Logging() bool {
+	return tr != nil && tr.Log
+}
+
+func (tr *Trace) Logf(format string, args ...interface{}) {
+	if tr == nil {
+		return
+	}
+	msg := fmt.Sprintf(format, args...)
+	if tr.Log {
+		log.Println(msg)
+	}
+	tr.lock.Lock()
+	defer tr.lock.Unlock()
+	if len(tr.Events) < TRACE_EVENTS {
+		tr.Events = append(tr.Events, msg)
+	}
+}
+
+// subset starts recording the solve of another subset of the throws.
+func (tr *Trace) subset() {
+	if tr == nil {
+		return
+	}
+	tr.lock.Lock()
+	defer tr.lock.Unlock()
+	tr.Subsets++
+	tr.Chunks = nil
+	tr.Clusters = nil
+	tr.Rejected = nil
+	tr.Timings = nil
+}
+
+// chunk starts a new record of a chunk being scored against throws.
+func (tr *Trace) chunk(c Chunk, throws int) {
+	tr.lock.Lock()
+	defer tr.lock.Unlock()
+	ct := &ChunkTrace{Chunk: c, Throws: throws, Layers: map[string]int{}}
+	for n, old := range tr.Chunks {
+		if old.Chunk == c {
+			tr.Chunks[n] = ct
+			return
+		}
+	}
+	tr.Chunks = append(tr.Chunks, ct)
+}
+
+func (tr *Trace) find(c Chunk) *ChunkTrace {
+	for _, ct := range tr.Chunks {
+		if ct.Chunk == c {
+			return ct
+		}
+	}
+	return nil
+}
+
+func (tr *Trace) layer(c Chunk, name string, score int) {
+	tr.lock.Lock()
+	defer tr.lock.Unlock()
+	if ct := tr.find(c); ct != nil {
+		ct.Layers[name] = score
+	}
+}
+
+func (tr *Trace) score(c Chunk, score int) {
+	tr.lock.Lock()
+	defer tr.lock.Unlock()
+	if ct := tr.find(c); ct != nil {
+		ct.Score = score
+	}
+}
+
+// Reject counts a chunk ruled out, and notes why when it is watched.
+func (tr *Trace) Reject(c Chunk, reason string) {
+	if tr == nil {
+		return
+	}
+	tr.lock.Lock()
+	defer tr.lock.Unlock()
+	if tr.Rejected == nil {
+		tr.Rejected = map[string]int{}
+	}
+	tr.Rejected[reason]++
+	if !tr.watching(c) {
+		return
+	}
+	ct := tr.find(c)
+	if ct == nil {
+		ct = &ChunkTrace{Chunk: c, Layers: map[string]int{}}
+		tr.Chunks = append(tr.Chunks, ct)
+	}
+	ct.Rejected = reason
+}
+
+// choose marks the chosen chunk, forgetting chunks chosen by earlier guesses.
+func (tr *Trace) choose(c Chunk) {
+	tr.lock.Lock()
+	defer tr.lock.Unlock()
+	kept := tr.Chunks[:0]
+	for _, ct := range tr.Chunks {
+		ct.Chosen = ct.Chunk == c
+		if ct.Chosen || tr.watching(ct.Chunk) {
+			kept = append(kept, ct)
+		}
+	}
+	tr.Chunks = kept
+}
+
+func (tr *Trace) watching(c Chunk) bool {
+	for _, w := range tr.Watch {
+		if w == c {
+			return true
+		}
+	}
+	return false
+}
+
+func (tr *Trace) clusters(cs []ClusterTrace) {
+	if tr == nil {
+		return
+	}
+	sort.Slice(cs, func(i, j int) bool { return cs[i].Score > cs[j].Score })
+	tr.lock.Lock()
+	defer tr.lock.Unlock()
+	tr.Clusters = cs
+}
+
+// Time adds how long a stage took since start.
+func (tr *Trace) Time(stage string, start time.Time) {
+	if tr == nil {
+		return
+	}
+	tr.lock.Lock()
+	defer tr.lock.Unlock()
+	if tr.Timings == nil {
+		tr.Timings = map[string]float64{}
+	}
+	tr.Timings[stage] += float64(time.Since(start)) / float64(time.Millisecond)
+}
+
+// total adds how long a whole solve took since start.
+func (tr *Trace) total(start time.Time) {
+	if tr == nil {
+		return
+	}
+	tr.lock.Lock()
+	defer tr.lock.Unlock()
+	tr.Total += float64(time.Since(start)) / float64(time.Millisecond)
+}
+
+// Summary is a line on the chosen chunk, the clusters and the time taken.
+func (tr *Trace) Summary() string {
+	if tr == nil {
+		return ""
+	}
+	tr.lock.Lock()
+	defer tr.lock.Unlock()
+	parts := []string{}
+	for _, ct := range tr.Chunks {
+		if !ct.Chosen {
+			continue
+		}
+		layers := []string{}
+		for _, name := range LayerNames() {
+			if s, ok := ct.Layers[name]; ok {
+				layers = append(layers, fmt.Sprintf("%s %d", name, s))
+			}
+		}
+		parts = append(parts, fmt.Sprintf("chunk %d,%d scored %d (%s)", ct.Chunk[0], ct.Chunk[1], ct.Score, strings.Join(layers, ", ")))
+	}
+	parts = append(parts, fmt.Sprintf("%d clusters", len(tr.Clusters)))
+	parts = append(parts, fmt.Sprintf("%d subsets in %.0fms", tr.Subsets, tr.Total))
+	return strings.Join(parts, "; ")
+}
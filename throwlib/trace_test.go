@@ -0,0 +1,87 @@
+package throwlib
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestTrace(t *testing.T) {
+	test := progressionTests[0]
+	sess := NewSession()
+	sess.Options.Trace = &Trace{Watch: []Chunk{test.goal}}
+	g := guessOf(sess, test.throws[:2]...)
+	tr := sess.Options.Trace
+
+	var chosen, goal *ChunkTrace
+	for _, ct := range tr.Chunks {
+		if ct.Chosen {
+			chosen = ct
+		}
+		if ct.Chunk == test.goal {
+			goal = ct
+		}
+	}
+	if chosen == nil || chosen.Chunk != g.Chunk {
+		t.Fatalf("expected the chosen chunk %v to be traced, got %#v", g.Chunk, tr.Chunks)
+	}
+	if len(chosen.Layers) == 0 || chosen.Score != sess.Scores[g.Chunk] {
+		t.Errorf("expected the chosen chunk's layers and score %d, got %#v", sess.Scores[g.Chunk], chosen)
+	}
+	if goal == nil || (len(goal.Layers) == 0 && goal.Rejected == "") {
+		t.Errorf("expected the watched chunk to be scored or rejected, got %#v", goal)
+	}
+	if len(tr.Clusters) == 0 || len(tr.Rejected) == 0 {
+		t.Errorf("expected clusters and rejections, got %d and %v", len(tr.Clusters), tr.Rejected)
+	}
+	for _, stage := range []string{"scoring", "clustering", "choosing"} {
+		if _, ok := tr.Timings[stage]; !ok {
+			t.Errorf("expected %s to be timed, got %v", stage, tr.Timings)
+		}
+	}
+	t.Log(tr.Summary())
+}
+
+func TestTraceRequest(t *testing.T) {
+	req := Request{Clips: []string{"/execute in minecraft:overworld run tp @s 294.96 116.93 -486.85 -499.05 -25.35"}}
+	res, err := NewResponse(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Trace != nil {
+		t.Errorf("expected no trace unless asked for")
+	}
+
+	req.Options.Trace = true
+	res, err = NewResponse(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Trace == nil || len(res.Trace.Chunks) != 1 || res.Trace.Chunks[0].Chunk != *res.Chunk {
+		t.Errorf("expected the chosen chunk traced, got %#v", res.Trace)
+	}
+}
+
+func TestTraceSubsets(t *testing.T) {
+	test := progressionTests[0]
+	outlier := NewThrow(test.throws[1].X+200, test.throws[1].Y, 30)
+	throws := append([]Throw{outlier}, test.throws...)
+
+	sess := NewSession()
+	sess.Options.Trace = &Trace{}
+	g := guessOf(sess, throws...)
+	tr := sess.Options.Trace
+	if tr.Subsets < 2 {
+		t.Fatalf("expected several subsets scored, got %d", tr.Subsets)
+	}
+
+	// the trace describes only the subset guessed from
+	alone := NewSession()
+	alone.Options.Trace = &Trace{}
+	guessOf(alone, g.Used...)
+	if !reflect.DeepEqual(tr.Rejected, alone.Options.Trace.Rejected) {
+		t.Errorf("rejections %v, expected those of the used throws %v", tr.Rejected, alone.Options.Trace.Rejected)
+	}
+	if tr.Total < tr.Timings["scoring"] {
+		t.Errorf("total %.1fms is under the last scoring %.1fms", tr.Total, tr.Timings["scoring"])
+	}
+}
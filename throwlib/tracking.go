@@ -0,0 +1,107 @@
+package throwlib
+
+import "errors"
+
+// Hypothesis is a group of throws that agree on one target stronghold. Clips
+// are the request clips behind the throws, when answering a request.
+type Hypothesis struct {
+	Chunk      [2]int `json:"chunk"`
+	Coords     [2]int `json:"coords"`
+	Confidence int    `json:"confidence"`
+	Clips      []int  `json:"clips,omitempty"`
+
+	Throws []Throw `json:"-"`
+	Guess  Guess   `json:"-"`
+
+	// positions of the first and last throw among those tracked
+	first, last int
+}
+
+// Track splits throws into hypotheses, ordered by when they were last thrown
+// towards. Groups where no chunk scores are left out, so the last hypothesis
+// holds the latest throw that scored, and Track fails with ErrNoScore when no
+// group does. The session is left describing that last hypothesis. Like
+// BestGuess, only the latest MAX_SUBSET_THROWS throws are tracked.
+func (s *Session) Track(ts ...Throw) ([]Hypothesis, error) {
+	if len(ts) > MAX_SUBSET_THROWS {
+		ts = ts[len(ts)-MAX_SUBSET_THROWS:]
+	}
+
+	groups := [][]Throw{}
+	spans := [][2]int{}
+	for i, t := range ts {
+		joined := -1
+		// the stronghold most recently thrown towards is the likeliest target
+		for n := len(groups) - 1; n >= 0; n-- {
+			with := append(append([]Throw{}, groups[n]...), t)
+			_, err := s.subsetGuess(with)
+			if errors.Is(err, ErrNoScore) {
+				continue
+			}
+			if err != nil {
+				return nil, err
+			}
+			groups[n] = with
+			joined = n
+			break
+		}
+		if joined == -1 {
+			groups = append(groups, []Throw{t})
+			spans = append(spans, [2]int{i, i})
+			continue
+		}
+		group, span := groups[joined], [2]int{spans[joined][0], i}
+		groups = append(groups[:joined], groups[joined+1:]...)
+		spans = append(spans[:joined], spans[joined+1:]...)
+		groups = append(groups, group)
+		spans = append(spans, span)
+	}
+
+	hyps := make([]Hypothesis, 0, len(groups))
+	described := true
+	for n, group := range groups {
+		g, err := s.BestGuessContext(s.context(), group...)
+		described = err == nil
+		if errors.Is(err, ErrNoScore) {
+			s.Options.Trace.Logf("no chunk scored for a group of %d throws", len(group))
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		x, y := s.profile().Staircase(g.Chunk)
+		hyps = append(hyps, Hypothesis{
+			Chunk:      g.Chunk,
+			Coords:     [2]int{x, y},
+			Confidence: g.Confidence,
+			Throws:     group,
+			Guess:      g,
+			first:      spans[n][0],
+			last:       spans[n][1],
+		})
+	}
+	if len(hyps) == 0 {
+		return nil, errorf(ErrNoScore, "no chunk scored for any of %d throws", len(ts))
+	}
+	if !described {
+		if _, err := s.subsetGuess(hyps[len(hyps)-1].Guess.Used); err != nil {
+			return nil, err
+		}
+	}
+	if len(hyps) > 1 {
+		s.Options.Trace.Logf("tracking %d strongholds", len(hyps))
+	}
+	return hyps, nil
+}
+
+// Crossed is whether the player has probably walked into another stronghold's
+// territory, with at least two throws on each side and the latest ones all
+// coming after the previous hypothesis. A lone or interleaved disagreeing
+// throw is more likely a misclick.
+func Crossed(hyps []Hypothesis) bool {
+	if len(hyps) < 2 {
+		return false
+	}
+	current, previous := hyps[len(hyps)-1], hyps[len(hyps)-2]
+	return len(current.Throws) > 1 && len(previous.Throws) > 1 && previous.last < current.first
+}
@@ -0,0 +1,151 @@
+package throwlib
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func throwAtChunk(x, y float64, c Chunk) Throw {
+	tx, ty := DefaultProfile.TargetOf(c)
+	return throwAt(x, y, float64(tx), float64(ty))
+}
+
+func TestTrackCrossing(t *testing.T) {
+	first := ChunkFromPosition(0, 2000)
+	second := ChunkFromPosition(-1732, -1000)
+	throws := []Throw{
+		throwAtChunk(-300, 1000, first),
+		throwAtChunk(-100, 1100, first),
+		throwAtChunk(-1200, -300, second),
+		throwAtChunk(-1000, -400, second),
+	}
+
+	hyps, err := NewSession().Track(throws...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(hyps) != 2 {
+		t.Fatalf("expected 2 hypotheses, got %d", len(hyps))
+	}
+	if !Crossed(hyps) {
+		t.Errorf("expected the player to have crossed")
+	}
+	if Chunk(hyps[1].Chunk).ChunkDist(second) > 64 {
+		t.Errorf("current hypothesis %s is far from %s", Chunk(hyps[1].Chunk), second)
+	}
+	if Crossed(hyps[:1]) {
+		t.Errorf("one hypothesis cannot have crossed")
+	}
+
+	req := Request{}
+	for _, th := range throws {
+		req.Clips = append(req.Clips, fmt.Sprintf("/execute in minecraft:overworld run tp @s %.2f 100.00 %.2f %.2f -32.00", th.X, th.Y, degsFromRads(th.A)))
+	}
+	res, err := NewResponse(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !res.Crossed || len(res.Hypotheses) != 2 {
+		t.Fatalf("expected a crossing response, got %d hypotheses", len(res.Hypotheses))
+	}
+	for n, d := range res.Diagnostics {
+		if want := n < 2; (d.Reason == ReasonElsewhere) != want {
+			t.Errorf("clip %d has reason %q", n, d.Reason)
+		}
+	}
+	if len(res.Keep) != 2 {
+		t.Errorf("expected to keep the 2 latest clips, got %d", len(res.Keep))
+	}
+}
+
+func TestTrackMisclick(t *testing.T) {
+	first := ChunkFromPosition(0, 2000)
+	throws := []Throw{
+		throwAtChunk(-300, 1000, first),
+		throwAtChunk(-1200, -300, ChunkFromPosition(-1732, -1000)),
+		throwAtChunk(-100, 1100, first),
+		throwAtChunk(200, 1200, first),
+	}
+	hyps, err := NewSession().Track(throws...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(hyps) != 2 || len(hyps[1].Throws) != 3 {
+		t.Fatalf("expected the misclick apart from 3 agreeing throws, got %d hypotheses", len(hyps))
+	}
+	if Crossed(hyps) {
+		t.Errorf("a misclick between agreeing throws is not a crossing")
+	}
+
+	// the response keeps the guess found before tracking
+	want := guessOf(NewSession(), throws...)
+	req := Request{}
+	for _, th := range throws {
+		req.Clips = append(req.Clips, fmt.Sprintf("/execute in minecraft:overworld run tp @s %.2f 100.00 %.2f %.2f -32.00", th.X, th.Y, degsFromRads(th.A)))
+	}
+	res, err := NewResponse(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Crossed || *res.Chunk != want.Chunk || res.Confidence != want.Confidence || res.Partial {
+		t.Errorf("expected the response to guess %v at %d, got %v at %d", want.Chunk, want.Confidence, *res.Chunk, res.Confidence)
+	}
+}
+
+func TestDropOldestThrows(t *testing.T) {
+	goal := ChunkFromPosition(0, 2000)
+	req := Request{}
+	throws := []Throw{}
+	for n := 0; n < MAX_SUBSET_THROWS+2; n++ {
+		th := throwAtChunk(float64(-400+n*60), float64(900+n*40), goal)
+		throws = append(throws, th)
+		req.Clips = append(req.Clips, fmt.Sprintf("/execute in minecraft:overworld run tp @s %.2f 100.00 %.2f %.2f -32.00", th.X, th.Y, degsFromRads(th.A)))
+	}
+
+	guess := guessOf(NewSession(), throws...)
+	if len(guess.Dropped) != 2 || guess.Dropped[0] != throws[0] || guess.Dropped[1] != throws[1] {
+		t.Fatalf("expected the 2 oldest throws dropped, got %v", guess.Dropped)
+	}
+	if len(guess.Rejected) < 2 || guess.Rejected[0] != throws[0] {
+		t.Errorf("expected dropped throws among the rejected, got %v", guess.Rejected)
+	}
+
+	res, err := NewResponse(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for n, d := range res.Diagnostics[:2] {
+		if d.Reason != ReasonDropped {
+			t.Errorf("clip %d has reason %q, expected dropped", n, d.Reason)
+		}
+	}
+	// more clips than the API takes are guessed offline without asking it
+	posted, err := PostRequest(req, false)
+	if err != nil || posted.Chunk == nil || *posted.Chunk != *res.Chunk {
+		t.Errorf("expected %d clips guessed offline at %v, got %v (%v)", len(req.Clips), res.Chunk, posted.Chunk, err)
+	}
+}
+
+func TestTrackUnscored(t *testing.T) {
+	goal := ChunkFromPosition(0, 2000)
+	throws := []Throw{
+		throwAtChunk(-300, 1000, goal),
+		throwAtChunk(-100, 1100, goal),
+		NewThrow(0, 30000, 0),
+	}
+	sess := NewSession()
+	hyps, err := sess.Track(throws...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(hyps) != 1 || Chunk(hyps[0].Chunk).ChunkDist(goal) > 64 {
+		t.Fatalf("expected only the hypothesis near %s, got %v", goal, hyps)
+	}
+	if len(sess.Throws) != 2 {
+		t.Errorf("expected the session to describe the scored throws, got %d", len(sess.Throws))
+	}
+	if _, err := sess.Track(throws[2]); !errors.Is(err, ErrNoScore) {
+		t.Errorf("expected no score when nothing scores, got %v", err)
+	}
+}
@@ -0,0 +1,113 @@
+package throwlib
+
+import (
+	"errors"
+	"math"
+)
+
+// chi-squared value for a 95% ellipse with two degrees of freedom
+const ELLIPSE_95 = 5.991
+
+var ErrNotEnoughThrows = errors.New("triangulation needs two aimed throws")
+var ErrParallelThrows = errors.New("throws are too close to parallel to triangulate")
+
+// Ellipse is in blocks. Major and Minor are one standard deviation, Angle is
+// the major axis in radians from +x, and Radius covers 95% along the major axis.
+type Ellipse struct {
+	Center [2]float64 `json:"center"`
+	Major  float64    `json:"major"`
+	Minor  float64    `json:"minor"`
+	Angle  float64    `json:"angle"`
+	Radius float64    `json:"radius"`
+}
+
+type Triangulation struct {
+	X, Y       float64
+	Covariance [2][2]float64
+	Ellipse    Ellipse
+	Residuals  []float64
+}
+
+// Triangulate finds the point closest to every throw's ray, weighting each
+// throw by how far its perpendicular error grows at that distance. Nearly
+// parallel pairs barely constrain the point and end up weighing little.
+func Triangulate(ts []Throw, sigma float64) (Triangulation, error) {
+	aimed := make([]Throw, 0, len(ts))
+	for _, t := range ts {
+		if t.Type == Overworld {
+			aimed = append(aimed, t)
+		}
+	}
+	if len(aimed) < 2 {
+		return Triangulation{}, ErrNotEnoughThrows
+	}
+
+	// start from equal weights, then refine using the distance to the fit
+	x, y := 0.0, 0.0
+	weights := make([]float64, len(aimed))
+	for i := range weights {
+		weights[i] = 1
+	}
+	var a [2][2]float64
+	for iter := 0; iter < 4; iter++ {
+		a = [2][2]float64{}
+		bx, by := 0.0, 0.0
+		for i, t := range aimed {
+			nx, ny := math.Cos(t.A), math.Sin(t.A)
+			w := weights[i]
+			proj := nx*t.X + ny*t.Y
+			a[0][0] += w * nx * nx
+			a[0][1] += w * nx * ny
+			a[1][1] += w * ny * ny
+			bx += w * nx * proj
+			by += w * ny * proj
+		}
+		a[1][0] = a[0][1]
+
+		det := a[0][0]*a[1][1] - a[0][1]*a[1][0]
+		if math.Abs(det) < 1e-9*(a[0][0]+a[1][1])*(a[0][0]+a[1][1]) {
+			return Triangulation{}, ErrParallelThrows
+		}
+		x = (a[1][1]*bx - a[0][1]*by) / det
+		y = (a[0][0]*by - a[1][0]*bx) / det
+
+		for i, t := range aimed {
+			r := math.Max(dist(x, y, t.X, t.Y), 16) * t.Spread(sigma)
+			weights[i] = 1 / (r * r)
+		}
+	}
+
+	det := a[0][0]*a[1][1] - a[0][1]*a[1][0]
+	tri := Triangulation{X: x, Y: y}
+	tri.Covariance = [2][2]float64{
+		{a[1][1] / det, -a[0][1] / det},
+		{-a[1][0] / det, a[0][0] / det},
+	}
+	tri.Ellipse = ellipseFromCovariance(x, y, tri.Covariance)
+	for _, t := range ts {
+		tri.Residuals = append(tri.Residuals, angleToPoint(t, x, y))
+	}
+	return tri, nil
+}
+
+func ellipseFromCovariance(x, y float64, cov [2][2]float64) Ellipse {
+	tr := cov[0][0] + cov[1][1]
+	det := cov[0][0]*cov[1][1] - cov[0][1]*cov[1][0]
+	disc := math.Sqrt(math.Max(tr*tr/4-det, 0))
+	major, minor := tr/2+disc, math.Max(tr/2-disc, 0)
+	angle := 0.5 * math.Atan2(2*cov[0][1], cov[0][0]-cov[1][1])
+	return Ellipse{
+		Center: [2]float64{x, y},
+		Major:  math.Sqrt(major),
+		Minor:  math.Sqrt(minor),
+		Angle:  angle,
+		Radius: math.Sqrt(ELLIPSE_95 * major),
+	}
+}
+
+// angleToPoint is the yaw error of a throw, were the eye flying to x, y.
+func angleToPoint(t Throw, x, y float64) float64 {
+	atan := math.Atan2(t.X-x, y-t.Y) + math.Pi*2
+	atan = math.Mod(atan, math.Pi*2)
+	return wrapRads(t.A - atan)
+}
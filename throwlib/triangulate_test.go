@@ -0,0 +1,55 @@
+package throwlib
+
+import (
+	"math"
+	"testing"
+)
+
+func throwAt(x, y, tx, ty float64) Throw {
+	t := Throw{X: x, Y: y, Type: Overworld}
+	t.A = wrapRads(math.Atan2(x-tx, ty-y))
+	return t
+}
+
+func TestTriangulateExact(t *testing.T) {
+	ts := []Throw{throwAt(0, 0, 1000, 1500), throwAt(400, -100, 1000, 1500), throwAt(-300, 200, 1000, 1500)}
+	tri, err := Triangulate(ts, radsFromDegs(YAW_SIGMA))
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if dist(tri.X, tri.Y, 1000, 1500) > 0.01 {
+		t.Errorf("fit %.2f,%.2f is not 1000,1500", tri.X, tri.Y)
+	}
+	for n, r := range tri.Residuals {
+		if math.Abs(r) > 1e-6 {
+			t.Errorf("throw %d has residual %f", n, r)
+		}
+	}
+	if tri.Ellipse.Radius <= 0 || tri.Ellipse.Minor > tri.Ellipse.Major {
+		t.Errorf("bad ellipse %#v", tri.Ellipse)
+	}
+}
+
+func TestTriangulateParallel(t *testing.T) {
+	ts := []Throw{NewThrow(0, 0, 30), NewThrow(100, 0, 30)}
+	if _, err := Triangulate(ts, radsFromDegs(YAW_SIGMA)); err != ErrParallelThrows {
+		t.Errorf("expected parallel error, got %v", err)
+	}
+	if _, err := Triangulate(ts[:1], radsFromDegs(YAW_SIGMA)); err != ErrNotEnoughThrows {
+		t.Errorf("expected not enough throws, got %v", err)
+	}
+}
+
+func TestTriangulateAccuracy(t *testing.T) {
+	distance, total := 0.0, 0
+	for _, test := range progressionTests {
+		tri, err := Triangulate(test.throws, radsFromDegs(YAW_SIGMA))
+		if err != nil {
+			continue
+		}
+		x, y := test.goal.Center()
+		distance += dist(tri.X, tri.Y, float64(x), float64(y))
+		total++
+	}
+	t.Logf("average least squares accuracy for %d samples: %.0f blocks", total, distance/float64(total))
+}